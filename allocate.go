@@ -0,0 +1,136 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// Allocation identifies how Number.Allocate distributes the units left over after rounding each part down.
+type Allocation int
+
+const (
+	// AllocateLargestRemainder gives the left over units to the parts with the largest remainders (the largest
+	// fractional parts, in units of the last decimal place), breaking ties in order, i.e. the Hamilton method.
+	AllocateLargestRemainder Allocation = iota
+
+	// AllocateInOrder gives the left over units to the parts in order, skipping any with no remainder.
+	AllocateInOrder
+)
+
+// String returns the name of the allocation method.
+func (a Allocation) String() string {
+	switch a {
+	case AllocateLargestRemainder:
+		return "AllocateLargestRemainder"
+	case AllocateInOrder:
+		return "AllocateInOrder"
+	default:
+		return "Allocation(" + strconv.Itoa(int(a)) + ")"
+	}
+}
+
+// Allocate splits x into parts proportional to weights, each rounded to n decimal places, such that the parts sum
+// exactly to x rounded to n decimal places (using Round), e.g. 100 split 3 ways (with equal weights) at n=2 is
+// 33.34, 33.33, 33.33.
+//
+// Each part is first rounded toward zero, then the units that are left over (in the last decimal place) are
+// distributed one at a time, using the given method, which is deterministic.
+//
+// The weights must be finite and non-negative, with a non-zero sum, otherwise ErrWeight or ErrDivisionByZero will
+// be returned, and x must be finite, otherwise ErrNotFinite will be returned.
+func (x Number) Allocate(weights []Number, n int, method Allocation) ([]Number, error) {
+	if !x.isFinite() {
+		return nil, ErrNotFinite
+	}
+	if method != AllocateLargestRemainder && method != AllocateInOrder {
+		return nil, ErrAllocation
+	}
+
+	sum := new(big.Rat)
+	ratios := make([]*big.Rat, len(weights))
+	for i, w := range weights {
+		if !w.isFinite() || w.Sign() < 0 {
+			return nil, ErrWeight
+		}
+		ratios[i] = w.coefficient().rat()
+		sum.Add(sum, ratios[i])
+	}
+	if sum.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+
+	// work in units of 10 ^ -n, using the magnitude of the total
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(n))), nil))
+	if n < 0 {
+		scale.Inv(scale)
+	}
+	total := x.Round(n)
+	units := new(big.Rat).Mul(total.Abs().coefficient().rat(), scale)
+
+	var (
+		parts      = make([]*big.Int, len(weights))
+		remainders = make([]*big.Rat, len(weights))
+		left       = new(big.Int).Set(units.Num())
+	)
+	for i, r := range ratios {
+		share := new(big.Rat).Mul(units, r)
+		share.Quo(share, sum)
+		parts[i] = new(big.Int).Quo(share.Num(), share.Denom())
+		remainders[i] = share.Sub(share, new(big.Rat).SetInt(parts[i]))
+		left.Sub(left, parts[i])
+	}
+
+	// there are always fewer left over units than parts with a non-zero remainder, as they sum to the same value
+	order := make([]int, 0, len(weights))
+	for i := range remainders {
+		if remainders[i].Sign() != 0 {
+			order = append(order, i)
+		}
+	}
+	if method == AllocateLargestRemainder {
+		sort.SliceStable(order, func(i, j int) bool {
+			return remainders[order[i]].Cmp(remainders[order[j]]) > 0
+		})
+	}
+	one := big.NewInt(1)
+	for i := 0; left.Sign() > 0; i++ {
+		parts[order[i]].Add(parts[order[i]], one)
+		left.Sub(left, one)
+	}
+
+	result := make([]Number, len(parts))
+	for i, p := range parts {
+		v := new(big.Rat).SetInt(p)
+		v.Quo(v, scale)
+		if total.Sign() < 0 {
+			v.Neg(v)
+		}
+		result[i], _ = newNumberTerminating(v)
+	}
+	return result, nil
+}
+
+// abs returns the absolute value of an int.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
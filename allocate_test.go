@@ -0,0 +1,145 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func numbers(values ...string) []Number {
+	result := make([]Number, len(values))
+	for i, v := range values {
+		result[i], _ = ParseNumber(v)
+	}
+	return result
+}
+
+func ExampleNumber_Allocate() {
+	total, _ := ParseNumber("100")
+	fmt.Println(total.Allocate(numbers("1", "1", "1"), 2, AllocateLargestRemainder))
+	fmt.Println(total.Allocate(numbers("0.2", "0.3", "0.5"), 0, AllocateLargestRemainder))
+
+	total, _ = ParseNumber("-10")
+	fmt.Println(total.Allocate(numbers("1", "2", "2", "1"), 0, AllocateLargestRemainder))
+	fmt.Println(total.Allocate(numbers("1", "2", "2", "1"), 0, AllocateInOrder))
+
+	// Output:
+	// [33.34 33.33 33.33] <nil>
+	// [20 30 50] <nil>
+	// [-2 -3 -3 -2] <nil>
+	// [-2 -4 -3 -1] <nil>
+}
+
+func TestNumber_Allocate(t *testing.T) {
+	for _, tc := range []struct {
+		Total   string
+		Weights []string
+		N       int
+		Method  Allocation
+		Parts   string
+	}{
+		{"1", []string{"1", "1", "1"}, 0, AllocateLargestRemainder, "[1 0 0]"},
+		{"2", []string{"1", "1", "1"}, 0, AllocateLargestRemainder, "[1 1 0]"},
+		{"2", []string{"1", "1", "2"}, 0, AllocateLargestRemainder, "[1 0 1]"},
+		{"1", []string{"1", "2"}, 0, AllocateLargestRemainder, "[0 1]"},
+		{"1", []string{"1", "2"}, 0, AllocateInOrder, "[1 0]"},
+		{"0.05", []string{"1", "1"}, 1, AllocateLargestRemainder, "[0.1 0]"},
+		{"0.04", []string{"1", "1"}, 1, AllocateLargestRemainder, "[0 0]"},
+		{"1550", []string{"1", "1", "1"}, -2, AllocateLargestRemainder, "[600 500 500]"},
+		{"10", []string{"0", "1", "0"}, 2, AllocateLargestRemainder, "[0 10 0]"},
+		{"7", []string{"1"}, 0, AllocateInOrder, "[7]"},
+		{"1e-3", []string{"1", "3"}, 4, AllocateLargestRemainder, "[0.0003 0.0007]"},
+		{"0", []string{"1", "3"}, 4, AllocateLargestRemainder, "[0 0]"},
+	} {
+		total, _ := ParseNumber(tc.Total)
+		parts, err := total.Allocate(numbers(tc.Weights...), tc.N, tc.Method)
+		if err != nil || fmt.Sprint(parts) != tc.Parts {
+			t.Error(tc.Total, tc.Weights, tc.N, tc.Method, parts, err)
+		}
+	}
+}
+
+func TestNumber_Allocate_sum(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		total, _ := ParseNumber(strconv.FormatFloat((r.Float64()-0.5)*1e4, 'f', r.Intn(6), 64))
+		weights := make([]Number, 1+r.Intn(8))
+		for j := range weights {
+			weights[j], _ = ParseNumber(strconv.Itoa(r.Intn(5)))
+		}
+		weights[0], _ = ParseNumber("0.5")
+		n := r.Intn(5) - 1
+		for _, method := range []Allocation{AllocateLargestRemainder, AllocateInOrder} {
+			parts, err := total.Allocate(weights, n, method)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sum := Number{}
+			for j, p := range parts {
+				if p.Round(n).Cmp(p) != 0 {
+					t.Fatal(total, j, p)
+				}
+				if p.Abs().Cmp(total.Round(n).Abs()) > 0 {
+					t.Fatal(total, p)
+				}
+				sum = sum.Add(p)
+			}
+			if sum.Cmp(total.Round(n)) != 0 {
+				t.Fatal(total, weights, n, method, parts, sum)
+			}
+		}
+	}
+}
+
+func TestAllocation_String(t *testing.T) {
+	if s := AllocateLargestRemainder.String(); s != "AllocateLargestRemainder" {
+		t.Error(s)
+	}
+	if s := AllocateInOrder.String(); s != "AllocateInOrder" {
+		t.Error(s)
+	}
+	if s := Allocation(-1).String(); s != "Allocation(-1)" {
+		t.Error(s)
+	}
+}
+
+func TestNumber_Allocate_errors(t *testing.T) {
+	one, _ := ParseNumber("1")
+	for _, tc := range []struct {
+		Total   Number
+		Weights []Number
+		Method  Allocation
+		Err     error
+	}{
+		{Inf(1), numbers("1"), AllocateLargestRemainder, ErrNotFinite},
+		{one, nil, AllocateLargestRemainder, ErrDivisionByZero},
+		{one, numbers("0", "0"), AllocateLargestRemainder, ErrDivisionByZero},
+		{one, numbers("1", "-1"), AllocateLargestRemainder, ErrWeight},
+		{one, []Number{one, NaN()}, AllocateLargestRemainder, ErrWeight},
+		{one, numbers("1"), Allocation(-1), ErrAllocation},
+	} {
+		if _, err := tc.Total.Allocate(tc.Weights, 2, tc.Method); err != tc.Err {
+			t.Error(tc.Total, tc.Weights, err)
+		}
+	}
+	if s := Allocation(9).String(); s != "Allocation(9)" {
+		t.Error(s)
+	}
+}
@@ -0,0 +1,392 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"strings"
+)
+
+// Sign returns -1 if x is negative, 0 if x is zero (or NaN), or +1 if x is positive.
+func (x Number) Sign() int {
+	switch {
+	case x.IsZero() || x.IsNaN():
+		return 0
+	case x.signbit:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// Neg returns -x, note that the negation of zero (including negative zero) is zero.
+func (x Number) Neg() Number {
+	switch {
+	case x.IsNaN():
+	case x.IsZero():
+		x.signbit = false
+	default:
+		x.signbit = !x.signbit
+	}
+	return x
+}
+
+// Abs returns |x|.
+func (x Number) Abs() Number {
+	x.signbit = false
+	return x
+}
+
+// Cmp compares x and y, returning -1 if x < y, 0 if x == y, or +1 if x > y, note that this compares the actual
+// value, and is unaffected by differences in representation, unlike ==.
+func (x Number) Cmp(y Number) int {
+	if !x.isFinite() || !y.isFinite() {
+		return cmpInt(x.rank(), y.rank())
+	}
+	if sx, sy := x.Sign(), y.Sign(); sx != sy {
+		if sx < sy {
+			return -1
+		}
+		return 1
+	}
+	c := cmpMagnitude(x, y)
+	if x.signbit {
+		c = -c
+	}
+	return c
+}
+
+// Add returns the exact sum x+y, note that the number of digits in the result (and therefore the memory used) grows
+// with the difference between the exponents, e.g. 1e100000000+1 has 100000001 digits, so use Limits to restrict
+// untrusted input, and that NaN is returned if the exact result would have more digits than the max int.
+func (x Number) Add(y Number) Number {
+	if !x.isFinite() || !y.isFinite() {
+		switch {
+		case x.IsNaN() || y.IsNaN() || (x.IsInf(0) && y.IsInf(0) && x.signbit != y.signbit):
+			return NaN()
+		case x.IsInf(0):
+			return x
+		default:
+			return y
+		}
+	}
+	// zero must be handled separately, as aligning would pad it with leading zeros
+	switch {
+	case x.IsZero() && y.IsZero():
+		// zero results are always positive zero, see Class
+		return Number{}
+	case y.IsZero():
+		return x
+	case x.IsZero():
+		return y
+	}
+	a, b, ok := alignCoefficients(x.coefficient(), y.coefficient())
+	if !ok {
+		return NaN()
+	}
+	if a.signbit == b.signbit {
+		return coefficient{signbit: a.signbit, digits: addDigits(a.digits, b.digits), exp: a.exp}.number()
+	}
+	if cmpDigits(a.digits, b.digits) < 0 {
+		a, b = b, a
+	}
+	return coefficient{signbit: a.signbit, digits: subDigits(a.digits, b.digits), exp: a.exp}.number()
+}
+
+// Sub returns the exact difference x-y, see Add.
+func (x Number) Sub(y Number) Number {
+	return x.Add(y.Neg())
+}
+
+// Mul returns the exact product x*y, or an infinity if the exponent overflows, see Number.
+func (x Number) Mul(y Number) Number {
+	if !x.isFinite() || !y.isFinite() {
+		if x.IsNaN() || y.IsNaN() || x.IsZero() || y.IsZero() {
+			return NaN()
+		}
+		return Inf(1).signed(x.signbit != y.signbit)
+	}
+	a, b := x.coefficient(), y.coefficient()
+	if len(a.digits) == 0 || len(b.digits) == 0 {
+		return Number{}
+	}
+	signbit := a.signbit != b.signbit
+	if a.exp > 0 && b.exp > maxInt-a.exp {
+		return Inf(1).signed(signbit)
+	}
+	digits := mulDigits(a.digits, b.digits)
+	if a.exp < 0 && b.exp < minInt-a.exp {
+		// discard the digits below 10 ^ min int
+		return coefficient{signbit: signbit, digits: truncateDigits(digits, subSaturating(minInt-a.exp, b.exp)), exp: minInt}.number()
+	}
+	return coefficient{signbit: signbit, digits: digits, exp: a.exp + b.exp}.number()
+}
+
+// rank orders the non-finite numbers, relative to the finite numbers, which have a rank of 0.
+func (x Number) rank() int {
+	switch {
+	case x.IsNaN():
+		return -2
+	case x.IsInf(-1):
+		return -1
+	case x.IsInf(1):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// signed returns x, with the signbit set if signbit is true.
+func (x Number) signed(signbit bool) Number {
+	x.signbit = signbit
+	return x
+}
+
+// cmpInt compares two ints.
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// coefficient is an alternate representation of a number, digits x 10 ^ exp, used to implement arithmetic, where
+// digits are ASCII, and (once normalised) have no leading zeros, an empty digits representing zero.
+type coefficient struct {
+	signbit bool
+	digits  []byte
+	exp     int
+}
+
+// coefficient converts x to a normalised coefficient, discarding any digits below 10 ^ min int.
+func (x Number) coefficient() coefficient {
+	digits := make([]byte, 0, len(x.integer)+len(x.fractional))
+	digits = append(digits, x.integer...)
+	digits = append(digits, x.fractional...)
+	if x.exponential < minInt+len(x.fractional) {
+		// the last digit is below 10 ^ min int
+		return coefficient{
+			signbit: x.signbit,
+			digits:  truncateDigits(digits, len(x.fractional)-(x.exponential-minInt)),
+			exp:     minInt,
+		}.normalise()
+	}
+	return coefficient{
+		signbit: x.signbit,
+		digits:  digits,
+		exp:     x.exponential - len(x.fractional),
+	}.normalise()
+}
+
+// normalise strips leading and trailing zeros from the digits (adjusting exp), clearing the signbit for zero, note
+// that trailing zeros are retained if the exp would otherwise exceed the max int.
+func (c coefficient) normalise() coefficient {
+	for len(c.digits) != 0 && c.digits[0] == '0' {
+		c.digits = c.digits[1:]
+	}
+	for l := len(c.digits); l != 0 && c.digits[l-1] == '0' && c.exp != maxInt; l-- {
+		c.digits = c.digits[:l-1]
+		c.exp++
+	}
+	if len(c.digits) == 0 {
+		c.signbit = false
+		c.exp = 0
+	}
+	return c
+}
+
+// number converts the coefficient back into a Number, placing the decimal point within the digits, where possible.
+func (c coefficient) number() Number {
+	c = c.normalise()
+	var x Number
+	switch {
+	case c.exp >= 0:
+		x, _ = NewNumber(c.signbit, string(c.digits), "", c.exp, true)
+	case len(c.digits)+c.exp > 0:
+		p := len(c.digits) + c.exp
+		x, _ = NewNumber(c.signbit, string(c.digits[:p]), string(c.digits[p:]), 0, true)
+	default:
+		x, _ = NewNumber(c.signbit, "", string(c.digits), c.exp+len(c.digits), true)
+	}
+	return x
+}
+
+// alignCoefficients returns a and b with the same exp, by appending zeros to the one with the larger exp, or false if
+// the number of digits would overflow int.
+func alignCoefficients(a, b coefficient) (coefficient, coefficient, bool) {
+	shift := func(c coefficient, exp int) (coefficient, bool) {
+		n := addSaturating(len(c.digits), subSaturating(c.exp, exp))
+		if n == maxInt {
+			return coefficient{}, false
+		}
+		digits := make([]byte, len(c.digits), n)
+		copy(digits, c.digits)
+		for len(digits) < n {
+			digits = append(digits, '0')
+		}
+		return coefficient{signbit: c.signbit, digits: digits, exp: exp}, true
+	}
+	ok := true
+	if a.exp > b.exp {
+		a, ok = shift(a, b.exp)
+	} else if b.exp > a.exp {
+		b, ok = shift(b, a.exp)
+	}
+	return a, b, ok
+}
+
+// cmpMagnitude compares |x| and |y|, for finite x and y, which is exact for any exponentials, unlike comparing the
+// coefficients, which discard digits below 10 ^ min int.
+func cmpMagnitude(x, y Number) int {
+	a := strings.Trim(x.integer+x.fractional, "0")
+	b := strings.Trim(y.integer+y.fractional, "0")
+	if a == "" || b == "" {
+		return cmpInt(len(a), len(b))
+	}
+	// the most significant digit of x is at 10 ^ (x.exponential + len(x.integer) - 1 - leading zeros), and the
+	// same for y, so compare the positions (adding 1 to each), without overflow
+	ma := len(x.integer) - strings.IndexAny(x.integer+x.fractional, "123456789")
+	mb := len(y.integer) - strings.IndexAny(y.integer+y.fractional, "123456789")
+	if c := cmpExponent(x.exponential, ma, y.exponential, mb); c != 0 {
+		return c
+	}
+	// same magnitude, compare digit by digit, where a shorter prefix is smaller
+	return strings.Compare(a, b)
+}
+
+// cmpAligned compares two sequences of digits, aligned at the most significant digit, i.e. as if each were the
+// digits of a fraction, treating missing (trailing) digits as zeros.
+func cmpAligned(a, b []byte) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		da, db := byte('0'), byte('0')
+		if i < len(a) {
+			da = a[i]
+		}
+		if i < len(b) {
+			db = b[i]
+		}
+		if da != db {
+			if da < db {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// cmpDigits compares two uints expressed as digits, which must not have leading zeros.
+func cmpDigits(a, b []byte) int {
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// addDigits returns a+b, for two uints expressed as digits.
+func addDigits(a, b []byte) []byte {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	result := make([]byte, len(a)+1)
+	carry := byte(0)
+	for i := 1; i <= len(a); i++ {
+		d := a[len(a)-i] - '0' + carry
+		if i <= len(b) {
+			d += b[len(b)-i] - '0'
+		}
+		carry = d / 10
+		result[len(result)-i] = d%10 + '0'
+	}
+	result[0] = carry + '0'
+	return trimDigits(result)
+}
+
+// subDigits returns a-b, for two uints expressed as digits, where a >= b.
+func subDigits(a, b []byte) []byte {
+	result := make([]byte, len(a))
+	borrow := byte(0)
+	for i := 1; i <= len(a); i++ {
+		d := int(a[len(a)-i]-'0') - int(borrow)
+		if i <= len(b) {
+			d -= int(b[len(b)-i] - '0')
+		}
+		borrow = 0
+		if d < 0 {
+			d += 10
+			borrow = 1
+		}
+		result[len(result)-i] = byte(d) + '0'
+	}
+	return trimDigits(result)
+}
+
+// mulDigits returns a*b, for two uints expressed as digits.
+func mulDigits(a, b []byte) []byte {
+	acc := make([]int, len(a)+len(b))
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			acc[i+j+1] += int(a[i]-'0') * int(b[j]-'0')
+		}
+		// propagate carries as we go, to keep the accumulator small
+		for k := i + len(b); k > i; k-- {
+			acc[k-1] += acc[k] / 10
+			acc[k] %= 10
+		}
+	}
+	result := make([]byte, len(acc))
+	for i, d := range acc {
+		result[i] = byte(d) + '0'
+	}
+	return trimDigits(result)
+}
+
+// cmpExponent compares a+i and b+j, without overflow, where i and j are offsets bounded by the number of digits.
+func cmpExponent(a, i, b, j int) int {
+	// if a-b saturates, the difference must be larger than any difference between the offsets
+	return cmpInt(subSaturating(a, b), j-i)
+}
+
+// truncateDigits discards the last n digits, returning nil if there are n or fewer digits.
+func truncateDigits(digits []byte, n int) []byte {
+	if n >= len(digits) {
+		return nil
+	}
+	return digits[:len(digits)-n]
+}
+
+// trimDigits strips any leading zeros.
+func trimDigits(digits []byte) []byte {
+	for len(digits) != 0 && digits[0] == '0' {
+		digits = digits[1:]
+	}
+	return digits
+}
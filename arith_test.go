@@ -0,0 +1,318 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func ExampleNumber_Add() {
+	a, _ := ParseNumber("0.1")
+	b, _ := ParseNumber("0.2")
+	c, _ := ParseNumber("-1e-30")
+
+	fmt.Println(a.Add(b))
+	fmt.Println(a.Add(b).Add(c))
+	fmt.Println(a.Sub(b))
+	fmt.Println(a.Mul(b).Mul(c))
+	fmt.Println(c.Neg(), c.Abs(), c.Sign())
+	fmt.Println(a.Add(b).Cmp(a), a.Cmp(a.Add(b)), a.Add(b).Sub(b).Cmp(a))
+
+	// results can be used with the rest of the package
+	fmt.Println(Join(Apply(a.Add(b).Add(c).Runes())(2)))
+
+	// Output:
+	// 0.3
+	// 0.299999999999999999999999999999
+	// -0.1
+	// -0.00000000000000000000000000000002
+	// 0.000000000000000000000000000001 0.000000000000000000000000000001 -1
+	// 1 -1 0
+	// 0.3 true
+}
+
+func TestNumber_Cmp(t *testing.T) {
+	type TestCase struct {
+		A, B string
+		Cmp  int
+	}
+
+	testCases := []TestCase{
+		{A: "0", B: "-0", Cmp: 0},
+		{A: "0", B: "1e-100", Cmp: -1},
+		{A: "-1", B: "0", Cmp: -1},
+		{A: "10", B: "1e1", Cmp: 0},
+		{A: "10.5", B: "1.05e1", Cmp: 0},
+		{A: "-2", B: "-1", Cmp: -1},
+		{A: "99", B: "100", Cmp: -1},
+		{A: "1.0001", B: "1.0002", Cmp: -1},
+		{A: "1.0001", B: "1", Cmp: 1},
+		{A: "-1.0001", B: "-1", Cmp: -1},
+	}
+
+	for i, testCase := range testCases {
+		name := fmt.Sprintf("TestNumber_Cmp_#%d", i+1)
+
+		a, _ := ParseNumber(testCase.A)
+		b, _ := ParseNumber(testCase.B)
+
+		if c := a.Cmp(b); c != testCase.Cmp {
+			t.Error(name, "a.Cmp(b)", c, "!= expected", testCase.Cmp)
+		}
+
+		if c := b.Cmp(a); c != -testCase.Cmp {
+			t.Error(name, "b.Cmp(a)", c, "!= expected", -testCase.Cmp)
+		}
+	}
+}
+
+func TestNumber_arithmetic(t *testing.T) {
+	var (
+		rng    = rand.New(rand.NewSource(1))
+		random = func() string {
+			s := fmt.Sprintf("%d.%de%d", rng.Int63n(1<<uint(rng.Intn(62)+1)), rng.Int63(), rng.Intn(41)-20)
+			if rng.Intn(2) == 0 {
+				s = "-" + s
+			}
+			return s
+		}
+		rat = func(x Number) *big.Rat {
+			r, ok := new(big.Rat).SetString(x.String())
+			if !ok {
+				t.Fatal(x)
+			}
+			return r
+		}
+	)
+
+	for i := 0; i < 5000; i++ {
+		a, err := ParseNumber(random())
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ParseNumber(random())
+		if err != nil {
+			t.Fatal(err)
+		}
+		ra, rb := rat(a), rat(b)
+
+		if v, e := rat(a.Add(b)), new(big.Rat).Add(ra, rb); v.Cmp(e) != 0 {
+			t.Fatal(a, "+", b, "=", v, "!= expected", e)
+		}
+
+		if v, e := rat(a.Sub(b)), new(big.Rat).Sub(ra, rb); v.Cmp(e) != 0 {
+			t.Fatal(a, "-", b, "=", v, "!= expected", e)
+		}
+
+		if v, e := rat(a.Mul(b)), new(big.Rat).Mul(ra, rb); v.Cmp(e) != 0 {
+			t.Fatal(a, "*", b, "=", v, "!= expected", e)
+		}
+
+		if v, e := a.Cmp(b), ra.Cmp(rb); v != e {
+			t.Fatal(a, "cmp", b, "=", v, "!= expected", e)
+		}
+	}
+}
+
+func TestNumber_Add_zero(t *testing.T) {
+	negZero, err := Parser{Specials: true}.ParseNumber("-0")
+	if err != nil || !negZero.IsZero() {
+		t.Fatal(negZero, err)
+	}
+	for _, s := range []string{"-0.01", "0.01", "-0.00284", "123.456", "-1e-20", "-5e10"} {
+		x, _ := ParseNumber(s)
+		for _, zero := range []Number{{}, negZero} {
+			if v := x.Add(zero); v.Cmp(x) != 0 {
+				t.Error(x, "+", zero, "=", v)
+			}
+			if v := zero.Add(x); v.Cmp(x) != 0 {
+				t.Error(zero, "+", x, "=", v)
+			}
+			if v := x.Sub(zero); v.Cmp(x) != 0 {
+				t.Error(x, "-", zero, "=", v)
+			}
+			if v := zero.Sub(x); v.Cmp(x.Neg()) != 0 {
+				t.Error(zero, "-", x, "=", v)
+			}
+		}
+	}
+	if v := (Number{}).Add(negZero); !v.IsZero() || v.Signbit() {
+		t.Error(v)
+	}
+	if v := negZero.Add(negZero); !v.IsZero() || v.Signbit() {
+		t.Error(v)
+	}
+}
+
+func TestNumber_Add_exponentRange(t *testing.T) {
+	for _, tc := range []struct {
+		A, B   string
+		Output string
+	}{
+		{"1e9223372036854775807", "1", "NaN"},
+		{"1e9223372036854775807", "1e-9223372036854775808", "NaN"},
+		{"-1e-9223372036854775808", "1e9223372036854775807", "NaN"},
+		{"1e9223372036854775807", "-1e9223372036854775807", "0"},
+		{"1e9223372036854775807", "2e9223372036854775807", "3e9223372036854775807"},
+		{"1e-9223372036854775808", "1e-9223372036854775808", "2e-9223372036854775808"},
+		{"1e20", "1e-20", "100000000000000000000.00000000000000000001"},
+	} {
+		a, _ := ParseNumber(tc.A)
+		b, _ := ParseNumber(tc.B)
+		// NOTE: the results can't be formatted, as that would expand the exponent
+		v := a.Add(b)
+		if v.IsNaN() != (tc.Output == "NaN") {
+			t.Error(tc.A, tc.B, v.Class())
+		} else if e, _ := ParseNumber(tc.Output); !v.IsNaN() && v.Cmp(e) != 0 {
+			t.Error(tc.A, tc.B, v.integer, v.fractional, v.exponential)
+		}
+	}
+}
+
+func TestNumber_Cmp_exponentRange(t *testing.T) {
+	for _, tc := range []struct {
+		A, B string
+		Cmp  int
+	}{
+		{"9e9223372036854775807", "1", 1},
+		{"12e9223372036854775807", "9e9223372036854775807", 1},
+		{"10e9223372036854775807", "1e9223372036854775807", 1},
+		{"0.1e9223372036854775807", "1e9223372036854775806", 0},
+		{"1e-9223372036854775808", "1e9223372036854775807", -1},
+		{"-1e-9223372036854775808", "-1e9223372036854775807", 1},
+		{"0.5e-9223372036854775808", "0.4e-9223372036854775808", 1},
+		{"0.05e-9223372036854775808", "0.5e-9223372036854775808", -1},
+		{"0.5e-9223372036854775808", "0", 1},
+		{"-0.0e-9223372036854775808", "0e9223372036854775807", 0},
+		{"123.45e-9223372036854775807", "1234.5e-9223372036854775808", 0},
+		{"123.45e-9223372036854775808", "1234.5e-9223372036854775808", -1},
+	} {
+		a, _ := ParseNumber(tc.A)
+		b, _ := ParseNumber(tc.B)
+		if c := a.Cmp(b); c != tc.Cmp {
+			t.Error(tc.A, tc.B, c)
+		}
+		if c := b.Cmp(a); c != -tc.Cmp {
+			t.Error(tc.B, tc.A, c)
+		}
+	}
+}
+
+func TestNumber_Mul_exponentRange(t *testing.T) {
+	parse := func(s string) Number {
+		x, err := ParseNumber(s)
+		if err != nil {
+			t.Fatal(s, err)
+		}
+		return x
+	}
+	for _, tc := range []struct {
+		A, B   string
+		Output Number
+	}{
+		{"1e9223372036854775807", "1e9223372036854775807", Inf(1)},
+		{"-1e9223372036854775807", "10", Inf(-1)},
+		{"1e9223372036854775807", "-1e9223372036854775807", Inf(-1)},
+		{"5e9223372036854775806", "2", parse("1e9223372036854775807")},
+		{"1e9223372036854775807", "0.1", parse("1e9223372036854775806")},
+		{"12e9223372036854775807", "1", parse("12e9223372036854775807")},
+		{"1e-9223372036854775808", "1e-9223372036854775808", Number{}},
+		{"-1e-9223372036854775808", "0.1", Number{}},
+		{"123e-9223372036854775807", "0.01", parse("12e-9223372036854775808")},
+		{"-3e-9223372036854775808", "2.5", parse("-7e-9223372036854775808")},
+		{"1e9223372036854775807", "1e-9223372036854775808", parse("0.1")},
+		{"0.5e-9223372036854775808", "10", Number{}},
+	} {
+		a, b := parse(tc.A), parse(tc.B)
+		if v := a.Mul(b); v.Class() != tc.Output.Class() || v.Cmp(tc.Output) != 0 {
+			t.Error(tc.A, tc.B, v.Class(), v.integer, v.fractional, v.exponential)
+		}
+	}
+}
+
+func TestSubSaturating(t *testing.T) {
+	for _, tc := range [][3]int{
+		{0, minInt, maxInt},
+		{-1, minInt, maxInt},
+		{-2, minInt, maxInt - 1},
+		{minInt, minInt, 0},
+		{maxInt, -1, maxInt},
+		{minInt, 1, minInt},
+		{5, 3, 2},
+	} {
+		if v := subSaturating(tc[0], tc[1]); v != tc[2] {
+			t.Error(tc, v)
+		}
+	}
+}
+
+func TestNumber_zeroResults(t *testing.T) {
+	negZero, _ := Parser{Specials: true}.ParseNumber("-0")
+	one, _ := ParseNumber("1")
+	quo := func(x, y Number, n int) Number {
+		q, _, _ := x.Quo(y, n, RoundHalfEven)
+		return q
+	}
+	for _, v := range []Number{
+		negZero.Add(negZero),
+		negZero.Sub(Number{}),
+		negZero.Sub(negZero),
+		one.Neg().Add(one),
+		negZero.Mul(Number{}),
+		negZero.Mul(one),
+		one.Neg().Mul(negZero),
+		one.Neg().Mul(Number{}),
+		quo(negZero, one, 2),
+		quo(one.Neg(), Inf(1), 2),
+		quo(one, Inf(-1), 2),
+		quo(one.Neg(), one, -2),
+	} {
+		if !v.IsZero() || v.Signbit() || v.Class() != ClassFinite {
+			t.Error(v, v.Class())
+		}
+	}
+}
+
+func TestCoefficient_number(t *testing.T) {
+	type TestCase struct {
+		Digits string
+		Exp    int
+		Output Number
+	}
+
+	testCases := []TestCase{
+		{Digits: "", Exp: 5, Output: Number{}},
+		{Digits: "000", Exp: -5, Output: Number{}},
+		{Digits: "12300", Exp: 2, Output: Number{integer: "123", exponential: 4}},
+		{Digits: "123", Exp: -1, Output: Number{integer: "12", fractional: "3"}},
+		{Digits: "123", Exp: -3, Output: Number{fractional: "123"}},
+		{Digits: "0123", Exp: -5, Output: Number{fractional: "123", exponential: -2}},
+	}
+
+	for i, testCase := range testCases {
+		name := fmt.Sprintf("TestCoefficient_number_#%d", i+1)
+
+		output := coefficient{digits: []byte(testCase.Digits), exp: testCase.Exp}.number()
+
+		if output != testCase.Output {
+			t.Error(name, "output", output, "!= expected", testCase.Output)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"errors"
+	"math/big"
+)
+
+// BigRat can be used with Runes(Parse(...)) to convert to a *big.Rat, which is always exact, note it will return an
+// error if ok is false, and that the size of the result is proportional to the exponential, so use Limits to
+// restrict untrusted input.
+func BigRat(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (*big.Rat, error) {
+	x, ok := NewNumberRunes(signbit, integer, fractional, exponential, ok)
+	if !ok {
+		return nil, errors.New("round.BigRat failed to parse string")
+	}
+	return x.coefficient().rat(), nil
+}
+
+// BigFloat is like BigRat, but returns a func to convert to a *big.Float, rounded to prec bits using mode, where a
+// prec of 0 uses the same default as big.Float.SetRat.
+func BigFloat(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) func(prec uint, mode big.RoundingMode) (*big.Float, error) {
+	return func(prec uint, mode big.RoundingMode) (*big.Float, error) {
+		r, err := BigRat(signbit, integer, fractional, exponential, ok)
+		if err != nil {
+			return nil, errors.New("round.BigFloat failed to parse string")
+		}
+		return new(big.Float).SetPrec(prec).SetMode(mode).SetRat(r), nil
+	}
+}
+
+// NewNumberBigFloat converts a *big.Float to a Number, exactly, which is always possible, as any binary fraction
+// has a finite decimal expansion, note that infinities and negative zero are converted to the equivalent special
+// values, see Class.
+func NewNumberBigFloat(f *big.Float) Number {
+	switch {
+	case f.IsInf():
+		return Inf(f.Sign())
+	case f.Sign() == 0:
+		return Number{signbit: f.Signbit()}
+	}
+	r, _ := f.Rat(nil)
+	x, _ := newNumberTerminating(r)
+	return x
+}
+
+// NewNumberBigRat converts a *big.Rat to a Number, rounded to n decimal places using mode, see Number.Quo, which is
+// used to perform the conversion.
+func NewNumberBigRat(r *big.Rat, n int, mode RoundingMode) (q Number, exact bool, err error) {
+	num, den := ratNumbers(r)
+	return num.Quo(den, n, mode)
+}
+
+// ratNumbers returns the numerator and denominator of r, as Numbers.
+func ratNumbers(r *big.Rat) (num Number, den Number) {
+	num, _ = NewNumber(r.Sign() < 0, new(big.Int).Abs(r.Num()).String(), "", 0, true)
+	den, _ = NewNumber(false, r.Denom().String(), "", 0, true)
+	return num, den
+}
+
+// BigRat converts the number to a *big.Rat, see the BigRat function, note that infinities and NaN will return
+// ErrNotFinite.
+func (x Number) BigRat() (*big.Rat, error) {
+	if !x.isFinite() {
+		return nil, ErrNotFinite
+	}
+	return BigRat(x.Runes())
+}
+
+// BigFloat converts the number to a *big.Float, see the BigFloat function, note that infinities and negative zero
+// are converted to their big.Float equivalents, but NaN will return ErrNotFinite.
+func (x Number) BigFloat(prec uint, mode big.RoundingMode) (*big.Float, error) {
+	switch x.Class() {
+	case ClassNaN:
+		return nil, ErrNotFinite
+	case ClassPositiveInf, ClassNegativeInf:
+		return new(big.Float).SetPrec(prec).SetMode(mode).SetInf(x.signbit), nil
+	case ClassNegativeZero:
+		return new(big.Float).SetPrec(prec).SetMode(mode).Neg(new(big.Float)), nil
+	}
+	return BigFloat(x.Runes())(prec, mode)
+}
+
+// rat converts the coefficient to an exact *big.Rat.
+func (c coefficient) rat() *big.Rat {
+	v := new(big.Int)
+	if len(c.digits) != 0 {
+		v.SetString(string(c.digits), 10)
+	}
+	if c.signbit {
+		v.Neg(v)
+	}
+	exp := c.exp
+	if exp < 0 {
+		exp = -exp
+	}
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+	if c.exp < 0 {
+		return new(big.Rat).SetFrac(v, pow)
+	}
+	return new(big.Rat).SetInt(v.Mul(v, pow))
+}
+
+// newNumberTerminating converts r to a Number, exactly, returning false if the decimal expansion doesn't terminate,
+// i.e. if the denominator has prime factors other than 2 and 5, using the identity a/(2^i 5^j) = a 2^(k-i) 5^(k-j)
+// / 10^k, where k = max(i, j).
+func newNumberTerminating(r *big.Rat) (Number, bool) {
+	den := new(big.Int).Set(r.Denom())
+	i := int(den.TrailingZeroBits())
+	den.Rsh(den, uint(i))
+	var (
+		j    int
+		q, m big.Int
+		five = big.NewInt(5)
+	)
+	for {
+		if q.QuoRem(den, five, &m); m.Sign() != 0 {
+			break
+		}
+		den.Set(&q)
+		j++
+	}
+	if den.Cmp(big.NewInt(1)) != 0 {
+		return Number{}, false
+	}
+	k := i
+	if j > k {
+		k = j
+	}
+	v := new(big.Int).Abs(r.Num())
+	v.Lsh(v, uint(k-i))
+	v.Mul(v, new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(k-j)), nil))
+	return coefficient{signbit: r.Sign() < 0, digits: []byte(v.String()), exp: -k}.number(), true
+}
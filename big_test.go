@@ -0,0 +1,140 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func ExampleBigRat() {
+	fmt.Println(BigRat(Runes(Parse("-1.25e-3"))))
+	fmt.Println(BigRat(Runes(Parse("12e3"))))
+
+	// Output:
+	// -1/800 <nil>
+	// 12000/1 <nil>
+}
+
+func ExampleBigFloat() {
+	f, _ := BigFloat(Runes(Parse("0.1")))(24, big.ToNearestEven)
+	fmt.Println(f.Text('g', 10), f.Prec())
+
+	// converting back is exact, showing the actual value of the float
+	fmt.Println(NewNumberBigFloat(f))
+
+	// Output:
+	// 0.1000000015 24
+	// 0.100000001490116119384765625
+}
+
+func ExampleNewNumberBigRat() {
+	fmt.Println(NewNumberBigRat(big.NewRat(2, 3), 4, RoundHalfEven))
+	fmt.Println(NewNumberBigRat(big.NewRat(-1, 8), 4, RoundHalfEven))
+
+	// Output:
+	// 0.6667 false <nil>
+	// -0.125 true <nil>
+}
+
+func TestBigRat_roundTrip(t *testing.T) {
+	for _, s := range []string{"0", "1", "-1", "0.5", "-123.456e-7", "98765432109876543210", "1e40", "-1e-40"} {
+		x, _ := ParseNumber(s)
+		r, err := x.BigRat()
+		if err != nil {
+			t.Fatal(s, err)
+		}
+		y, exact, err := NewNumberBigRat(r, 50, RoundHalfEven)
+		if err != nil || !exact || y.Cmp(x) != 0 {
+			t.Error(s, r, y, exact, err)
+		}
+	}
+	if _, err := BigRat(Runes(ParseString("invalid"))); err == nil {
+		t.Error(err)
+	}
+	if _, err := BigFloat(Runes(ParseString("invalid")))(53, big.ToNearestEven); err == nil {
+		t.Error(err)
+	}
+	if _, _, err := NewNumberBigRat(big.NewRat(1, 3), 2, RoundingMode(-1)); err != ErrRoundingMode {
+		t.Error(err)
+	}
+}
+
+func TestBigFloat_float64(t *testing.T) {
+	// converting to big.Float with 53 bits must match strconv.ParseFloat, and converting back must be exact
+	for _, f := range []float64{0, 1, -1, 0.1, 1.0 / 3, math.MaxFloat64, math.SmallestNonzeroFloat64, -2.5e-300, 123456789.123} {
+		x, _ := ParseNumber(String(f))
+		b, err := x.BigFloat(53, big.ToNearestEven)
+		if err != nil {
+			t.Fatal(f, err)
+		}
+		if v, _ := b.Float64(); v != f {
+			t.Error(f, v)
+		}
+		y := NewNumberBigFloat(big.NewFloat(f))
+		r, _ := y.BigRat()
+		if e, _ := new(big.Rat).SetString(big.NewFloat(f).Text('f', 1100)); r.Cmp(e) != 0 {
+			t.Error(f, y)
+		}
+	}
+}
+
+func TestBigFloat_modes(t *testing.T) {
+	for _, tc := range []struct {
+		Input string
+		Mode  big.RoundingMode
+		Out   string
+	}{
+		{"0.1", big.ToZero, "0.0999755859375"},
+		{"0.1", big.AwayFromZero, "0.10009765625"},
+		{"-0.1", big.ToNegativeInf, "-0.10009765625"},
+		{"-0.1", big.ToPositiveInf, "-0.0999755859375"},
+		{"0.1", big.ToNearestEven, "0.0999755859375"},
+	} {
+		f, err := BigFloat(Runes(ParseString(tc.Input)))(10, tc.Mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := NewNumberBigFloat(f).String(); s != tc.Out {
+			t.Error(tc.Input, tc.Mode, s)
+		}
+	}
+}
+
+func TestNumber_bigSpecials(t *testing.T) {
+	if _, err := Inf(1).BigRat(); err != ErrNotFinite {
+		t.Error(err)
+	}
+	if _, err := NaN().BigRat(); err != ErrNotFinite {
+		t.Error(err)
+	}
+	if _, err := NaN().BigFloat(53, big.ToNearestEven); err != ErrNotFinite {
+		t.Error(err)
+	}
+	z, _ := Parser{Specials: true}.ParseNumber("-0")
+	for _, x := range []Number{Inf(1), Inf(-1), z} {
+		f, err := x.BigFloat(53, big.ToNearestEven)
+		if err != nil {
+			t.Fatal(x, err)
+		}
+		if y := NewNumberBigFloat(f); y.Class() != x.Class() {
+			t.Error(x, f, y)
+		}
+	}
+}
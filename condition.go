@@ -0,0 +1,126 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"strconv"
+)
+
+// Direction is the direction a value was rounded in, see Condition.
+type Direction int
+
+const (
+	// DirectionDown indicates the value was decreased by rounding, i.e. toward negative infinity.
+	DirectionDown Direction = iota - 1
+
+	// DirectionExact indicates the value was unchanged by rounding.
+	DirectionExact
+
+	// DirectionUp indicates the value was increased by rounding, i.e. toward positive infinity.
+	DirectionUp
+)
+
+// String returns the name of the direction.
+func (d Direction) String() string {
+	switch d {
+	case DirectionDown:
+		return "DirectionDown"
+	case DirectionExact:
+		return "DirectionExact"
+	case DirectionUp:
+		return "DirectionUp"
+	default:
+		return "Direction(" + strconv.Itoa(int(d)) + ")"
+	}
+}
+
+// Condition describes the effect of rounding, in the spirit of the IEEE 754 inexact flag, see ApplyCondition.
+type Condition struct {
+	// Direction is the direction the value was rounded in, note that this is relative to the value, not the
+	// magnitude, e.g. rounding -1.235 to -1.24 is DirectionDown.
+	Direction Direction
+
+	// Discarded is the value of the digits that were discarded, with the same sign as the input, such that the
+	// input is equal to the input rounded toward zero, plus Discarded, e.g. 0.005 when rounding 1.235 to 2 decimal
+	// places, in any mode.
+	Discarded Number
+}
+
+// Inexact returns true if rounding changed the value.
+func (c Condition) Inexact() bool {
+	return c.Direction != DirectionExact
+}
+
+// ApplyCondition is like ApplyMode, but stores a Condition describing the effect of rounding in condition (if it
+// is not nil), which will be the zero value if ok is false, e.g. Join(ApplyCondition(Runes(Parse(v)))(2,
+// RoundHalfEven, &condition)).
+func ApplyCondition(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) func(n int, mode RoundingMode, condition *Condition) (signbit bool, integer []rune, fractional []rune, exponential int, ok bool) {
+	return func(n int, mode RoundingMode, condition *Condition) (bool, []rune, []rune, int, bool) {
+		if condition == nil {
+			condition = new(Condition)
+		}
+		*condition = Condition{}
+		if !ok || !mode.valid() {
+			return false, nil, nil, 0, false
+		}
+
+		var (
+			discarded []rune
+			zeros     int
+			increment bool
+			round     = roundModeFunc(mode, signbit)
+		)
+		result, resultExponential := applyFunc(integer, fractional, exponential, n, func(integer []rune, fractional []rune, z int) bool {
+			discarded, zeros = fractional, z
+			increment = round(integer, fractional, z)
+			return increment
+		})
+
+		// the discarded digits are a fraction of the last retained digit (at 10 ^ -n), preceded by zeros
+		switch {
+		case zeros != 0:
+			// all the digits were discarded
+			condition.Discarded, _ = NewNumberRunes(signbit, integer, fractional, exponential, true)
+		case n == minInt && len(discarded) != 0:
+			// -n would overflow
+			condition.Discarded, _ = NewNumberRunes(signbit, discarded[:1], discarded[1:], maxInt, true)
+		default:
+			condition.Discarded, _ = NewNumberRunes(signbit, nil, discarded, -n, true)
+		}
+		if !condition.Discarded.IsZero() {
+			if increment != signbit {
+				condition.Direction = DirectionUp
+			} else {
+				condition.Direction = DirectionDown
+			}
+		}
+
+		return signbit, result, nil, resultExponential, true
+	}
+}
+
+// RoundCondition returns the number rounded to n decimal places using the given mode, and a Condition describing
+// the effect, or false if the mode is not valid, see ApplyCondition, note that the special values are returned
+// unchanged, with the zero Condition.
+func (x Number) RoundCondition(n int, mode RoundingMode) (Number, Condition, bool) {
+	if x.isSpecial() {
+		return x, Condition{}, mode.valid()
+	}
+	var condition Condition
+	r, ok := NewNumberRunes(ApplyCondition(x.Runes())(n, mode, &condition))
+	return r, condition, ok
+}
@@ -0,0 +1,121 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleApplyCondition() {
+	for _, s := range []string{"1.235", "-1.235", "1.23", "1.2351"} {
+		var condition Condition
+		v, _ := Join(ApplyCondition(Runes(ParseString(s)))(2, RoundHalfEven, &condition))
+		fmt.Println(v, condition.Direction, condition.Discarded, condition.Inexact())
+	}
+
+	// Output:
+	// 1.24 DirectionUp 0.005 true
+	// -1.24 DirectionDown -0.005 true
+	// 1.23 DirectionExact 0 false
+	// 1.24 DirectionUp 0.0051 true
+}
+
+func TestApplyCondition(t *testing.T) {
+	for _, tc := range []struct {
+		Input     string
+		N         int
+		Mode      RoundingMode
+		Result    string
+		Direction Direction
+		Discarded string
+	}{
+		{"1.235", 2, RoundDown, "1.23", DirectionDown, "0.005"},
+		{"-1.235", 2, RoundDown, "-1.23", DirectionUp, "-0.005"},
+		{"1.235", 2, RoundCeiling, "1.24", DirectionUp, "0.005"},
+		{"-1.235", 2, RoundCeiling, "-1.23", DirectionUp, "-0.005"},
+		{"-1.235", 2, RoundFloor, "-1.24", DirectionDown, "-0.005"},
+		{"1.225", 2, RoundHalfEven, "1.22", DirectionDown, "0.005"},
+		{"1.2", 5, RoundUp, "1.2", DirectionExact, "0"},
+		{"0", 0, RoundUp, "0", DirectionExact, "0"},
+		{"1250", -2, RoundHalfEven, "1200", DirectionDown, "50"},
+		{"1250", -2, RoundHalfUp, "1300", DirectionUp, "50"},
+		{"123e10", -2, RoundDown, "1230000000000", DirectionExact, "0"},
+		{"0.0001", 2, RoundHalfEven, "0", DirectionDown, "0.0001"},
+		{"0.0001", 2, RoundUp, "0.01", DirectionUp, "0.0001"},
+		{"-0.0001", 2, RoundUp, "-0.01", DirectionDown, "-0.0001"},
+		{"7e-1000000", 0, RoundCeiling, "1", DirectionUp, "7e-1000000"},
+		{"99.99", 1, RoundHalfAwayFromZero, "100", DirectionUp, "0.09"},
+		{"5", -3, RoundHalfEven, "0", DirectionDown, "5"},
+	} {
+		var condition Condition
+		result, ok := Join(ApplyCondition(Runes(ParseString(tc.Input)))(tc.N, tc.Mode, &condition))
+		discarded, _ := ParseNumber(tc.Discarded)
+		if !ok || result != tc.Result || condition.Direction != tc.Direction || condition.Discarded.Cmp(discarded) != 0 {
+			t.Error(tc.Input, tc.N, tc.Mode, result, ok, condition.Direction, condition.Discarded)
+		}
+		// the result must match ApplyMode
+		if expected, _ := Join(ApplyMode(Runes(ParseString(tc.Input)))(tc.N, tc.Mode)); expected != result {
+			t.Error(tc.Input, expected, result)
+		}
+	}
+}
+
+func TestApplyCondition_reuse(t *testing.T) {
+	f := ApplyCondition(Runes(ParseString("1.96")))
+	for i := 0; i < 3; i++ {
+		var condition Condition
+		result, ok := Join(f(1, RoundUp, &condition))
+		if !ok || result != "2" || condition.Direction != DirectionUp || condition.Discarded.String() != "0.06" {
+			t.Error(i, result, ok, condition.Direction, condition.Discarded)
+		}
+		result, ok = Join(f(-1, RoundDown, &condition))
+		if !ok || result != "0" || condition.Direction != DirectionDown || condition.Discarded.String() != "1.96" {
+			t.Error(i, result, ok, condition.Direction, condition.Discarded)
+		}
+	}
+}
+
+func TestApplyCondition_invalid(t *testing.T) {
+	condition := Condition{Direction: DirectionUp}
+	if _, _, _, _, ok := ApplyCondition(Runes(ParseString("abc")))(2, RoundHalfEven, &condition); ok || condition != (Condition{}) {
+		t.Error(ok, condition)
+	}
+	if _, _, _, _, ok := ApplyCondition(Runes(ParseString("1.5")))(0, RoundingMode(-1), nil); ok {
+		t.Error(ok)
+	}
+	if _, _, _, _, ok := ApplyCondition(Runes(ParseString("1.5")))(0, RoundHalfEven, nil); !ok {
+		t.Error(ok)
+	}
+}
+
+func TestNumber_RoundCondition(t *testing.T) {
+	x, _ := ParseNumber("-2.5")
+	r, condition, ok := x.RoundCondition(0, RoundHalfEven)
+	if !ok || r.String() != "-2" || condition.Direction != DirectionUp || condition.Discarded.String() != "-0.5" {
+		t.Error(r, condition, ok)
+	}
+	if r, condition, ok := Inf(1).RoundCondition(0, RoundHalfEven); !ok || !r.IsInf(1) || condition.Inexact() {
+		t.Error(r, condition, ok)
+	}
+}
+
+func TestDirection_String(t *testing.T) {
+	if s := Direction(7).String(); s != "Direction(7)" {
+		t.Error(s)
+	}
+}
@@ -0,0 +1,236 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"strings"
+)
+
+// Currency describes how amounts of money are rounded, formatted, and parsed, see LookupCurrency.
+type Currency struct {
+	// Code is the ISO 4217 alphabetic code, e.g. USD.
+	Code string
+
+	// Symbol is the symbol for formatting (and parsing), e.g. $, which may be empty, in which case Code is used.
+	Symbol string
+
+	// MinorUnits is the number of decimal places, e.g. 2 for USD, or 0 for JPY.
+	MinorUnits int
+}
+
+// MoneyFormat configures Currency.Format, where the zero value formats like "$1234.50".
+type MoneyFormat struct {
+	// Locale configures the separators, defaults to (an unmodified) LocaleDefault if nil.
+	Locale *Locale
+
+	// Mode is the rounding mode, used to round to the MinorUnits of the currency.
+	Mode RoundingMode
+
+	// Code will use the Code of the currency rather than the Symbol, if true.
+	Code bool
+
+	// After will place the symbol (or code) after the amount, if true.
+	After bool
+
+	// Space will separate the symbol (or code) from the amount with a space, if true.
+	Space bool
+}
+
+// currencyMinorUnits is the ISO 4217 table of active currencies, mapped to their minor units.
+var currencyMinorUnits = map[string]int{
+	"AED": 2, "AFN": 2, "ALL": 2, "AMD": 2, "ANG": 2, "AOA": 2, "ARS": 2, "AUD": 2, "AWG": 2, "AZN": 2,
+	"BAM": 2, "BBD": 2, "BDT": 2, "BGN": 2, "BHD": 3, "BIF": 0, "BMD": 2, "BND": 2, "BOB": 2, "BOV": 2,
+	"BRL": 2, "BSD": 2, "BTN": 2, "BWP": 2, "BYN": 2, "BZD": 2, "CAD": 2, "CDF": 2, "CHE": 2, "CHF": 2,
+	"CHW": 2, "CLF": 4, "CLP": 0, "CNY": 2, "COP": 2, "COU": 2, "CRC": 2, "CUP": 2, "CVE": 2, "CZK": 2,
+	"DJF": 0, "DKK": 2, "DOP": 2, "DZD": 2, "EGP": 2, "ERN": 2, "ETB": 2, "EUR": 2, "FJD": 2, "FKP": 2,
+	"GBP": 2, "GEL": 2, "GHS": 2, "GIP": 2, "GMD": 2, "GNF": 0, "GTQ": 2, "GYD": 2, "HKD": 2, "HNL": 2,
+	"HTG": 2, "HUF": 2, "IDR": 2, "ILS": 2, "INR": 2, "IQD": 3, "IRR": 2, "ISK": 0, "JMD": 2, "JOD": 3,
+	"JPY": 0, "KES": 2, "KGS": 2, "KHR": 2, "KMF": 0, "KPW": 2, "KRW": 0, "KWD": 3, "KYD": 2, "KZT": 2,
+	"LAK": 2, "LBP": 2, "LKR": 2, "LRD": 2, "LSL": 2, "LYD": 3, "MAD": 2, "MDL": 2, "MGA": 2, "MKD": 2,
+	"MMK": 2, "MNT": 2, "MOP": 2, "MRU": 2, "MUR": 2, "MVR": 2, "MWK": 2, "MXN": 2, "MXV": 2, "MYR": 2,
+	"MZN": 2, "NAD": 2, "NGN": 2, "NIO": 2, "NOK": 2, "NPR": 2, "NZD": 2, "OMR": 3, "PAB": 2, "PEN": 2,
+	"PGK": 2, "PHP": 2, "PKR": 2, "PLN": 2, "PYG": 0, "QAR": 2, "RON": 2, "RSD": 2, "RUB": 2, "RWF": 0,
+	"SAR": 2, "SBD": 2, "SCR": 2, "SDG": 2, "SEK": 2, "SGD": 2, "SHP": 2, "SLE": 2, "SOS": 2, "SRD": 2,
+	"SSP": 2, "STN": 2, "SVC": 2, "SYP": 2, "SZL": 2, "THB": 2, "TJS": 2, "TMT": 2, "TND": 3, "TOP": 2,
+	"TRY": 2, "TTD": 2, "TWD": 2, "TZS": 2, "UAH": 2, "UGX": 0, "USD": 2, "USN": 2, "UYI": 0, "UYU": 2,
+	"UYW": 4, "UZS": 2, "VED": 2, "VES": 2, "VND": 0, "VUV": 0, "WST": 2, "XAF": 0, "XCD": 2, "XCG": 2,
+	"XOF": 0, "XPF": 0, "YER": 2, "ZAR": 2, "ZMW": 2, "ZWG": 2,
+}
+
+// currencySymbols maps codes to the symbol used by LookupCurrency, for the currencies that have a widely
+// recognised symbol.
+var currencySymbols = map[string]string{
+	"USD": "$", "EUR": "€", "GBP": "£", "JPY": "¥", "CNY": "¥", "INR": "₹", "KRW": "₩", "RUB": "₽", "TRY": "₺",
+	"ILS": "₪", "VND": "₫", "NGN": "₦", "THB": "฿", "PHP": "₱", "UAH": "₴",
+}
+
+// symbolCurrencies maps symbols to codes, for detecting the currency in Parser.ParseMoney, where ambiguous symbols
+// map to the most common currency, i.e. $ is USD, and ¥ is JPY.
+var symbolCurrencies = map[string]string{
+	"$": "USD", "€": "EUR", "£": "GBP", "¥": "JPY", "₹": "INR", "₩": "KRW", "₽": "RUB", "₺": "TRY", "₪": "ILS",
+	"₫": "VND", "₦": "NGN", "฿": "THB", "₱": "PHP", "₴": "UAH",
+}
+
+// LookupCurrency returns the Currency for an ISO 4217 code (case insensitive), from an embedded table of the active
+// currencies, or false if the code isn't known.
+func LookupCurrency(code string) (Currency, bool) {
+	code = strings.ToUpper(code)
+	minor, ok := currencyMinorUnits[code]
+	if !ok {
+		return Currency{}, false
+	}
+	return Currency{Code: code, Symbol: currencySymbols[code], MinorUnits: minor}, true
+}
+
+// Round returns x rounded to the minor units of the currency using mode, or false if mode is not valid.
+func (c Currency) Round(x Number, mode RoundingMode) (Number, bool) {
+	return x.RoundMode(c.MinorUnits, mode)
+}
+
+// Format returns x rounded to the minor units of the currency, and formatted with exactly that many decimal places,
+// and the symbol (or code), e.g. "-$1,234.50", or false if x is an infinity or NaN, or the mode is not valid, where
+// a nil f uses the zero value.
+func (c Currency) Format(x Number, f *MoneyFormat) (string, bool) {
+	if f == nil {
+		f = &MoneyFormat{}
+	}
+	if !x.isFinite() {
+		return "", false
+	}
+	x, ok := c.Round(x, f.Mode)
+	if !ok {
+		return "", false
+	}
+
+	locale := f.Locale.orDefault()
+	amount, _ := locale.Join(x.Abs().Runes())
+	if c.MinorUnits > 0 {
+		decimal := string(locale.decimal())
+		digits := 0
+		if i := strings.LastIndex(amount, decimal); i < 0 {
+			amount += decimal
+		} else {
+			digits = len(amount) - i - len(decimal)
+		}
+		amount += strings.Repeat("0", c.MinorUnits-digits)
+	}
+
+	symbol := c.Symbol
+	if f.Code || symbol == "" {
+		symbol = c.Code
+	}
+	separator := ""
+	if f.Space {
+		separator = " "
+	}
+	sign := ""
+	if x.Sign() < 0 {
+		sign = "-"
+	}
+	if f.After {
+		return sign + amount + separator + symbol, true
+	}
+	return sign + symbol + separator + amount, true
+}
+
+// ParseMoney parses an amount of money, like "$1,234.50", "USD 99.99", or "-12,50 €" (with LocaleDE), where the
+// currency code (case insensitive) or symbol may precede or follow the amount, and the amount is parsed in the
+// same way as ParseNumber, note that the amount is not rounded.
+//
+// If c is nil, the currency must be present, and is detected using LookupCurrency, or a symbol, where $ is assumed
+// to be USD, and ¥ is assumed to be JPY, otherwise ErrCurrency is returned. If c is not nil, the code or symbol of c
+// is optional, and any other currency will fail to parse.
+//
+// The sign may be placed either before or after a leading symbol, e.g. "-$5" or "$-5".
+func (p Parser) ParseMoney(s string, c *Currency) (Number, Currency, error) {
+	locale := p.locale()
+
+	// a and b are the bounds of what remains to parse
+	a := len(s) - len(strings.TrimLeftFunc(s, locale.space))
+	b := len(strings.TrimRightFunc(s, locale.space))
+	trim := func() {
+		a = b - len(strings.TrimLeftFunc(s[a:b], locale.space))
+		b = a + len(strings.TrimRightFunc(s[a:b], locale.space))
+	}
+
+	var sign byte
+	if a < b && (s[a] == '-' || s[a] == '+') {
+		sign = s[a]
+		a++
+		trim()
+	}
+
+	currency, found := Currency{}, false
+	if c != nil {
+		currency = *c
+		for _, token := range []string{c.Code, c.Symbol} {
+			if token == "" {
+				continue
+			}
+			if b-a >= len(token) && strings.EqualFold(s[a:a+len(token)], token) {
+				a, found = a+len(token), true
+			} else if b-a >= len(token) && strings.EqualFold(s[b-len(token):b], token) {
+				b, found = b-len(token), true
+			}
+			if found {
+				break
+			}
+		}
+	} else if v, token, ok := detectCurrency(s[a:b], true); ok {
+		currency, a, found = v, a+len(token), true
+	} else if v, token, ok := detectCurrency(s[a:b], false); ok {
+		currency, b, found = v, b-len(token), true
+	}
+	if c == nil && !found {
+		return Number{}, Currency{}, ErrCurrency
+	}
+	trim()
+
+	if sign != 0 && a < b && (s[a] == '-' || s[a] == '+') {
+		return Number{}, Currency{}, &ParseError{Input: s, Offset: a, Kind: ParseErrorSyntax}
+	}
+
+	x, err := p.ParseNumber(s[a:b])
+	if err != nil {
+		return Number{}, Currency{}, parseErrorWithin(err, s, a)
+	}
+	if sign == '-' {
+		x = x.Neg()
+	}
+	return x, currency, nil
+}
+
+// detectCurrency attempts to find a currency code or symbol at the start (or end) of s, returning the currency,
+// and the matched text.
+func detectCurrency(s string, prefix bool) (Currency, string, bool) {
+	if len(s) >= 3 {
+		code := s[len(s)-3:]
+		if prefix {
+			code = s[:3]
+		}
+		if c, ok := LookupCurrency(code); ok {
+			return c, code, true
+		}
+	}
+	for symbol, code := range symbolCurrencies {
+		if (prefix && strings.HasPrefix(s, symbol)) || (!prefix && strings.HasSuffix(s, symbol)) {
+			c, _ := LookupCurrency(code)
+			return c, symbol, true
+		}
+	}
+	return Currency{}, "", false
+}
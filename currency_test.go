@@ -0,0 +1,174 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleCurrency_Format() {
+	x, _ := ParseNumber("-1234.5")
+	for _, code := range []string{"USD", "JPY", "BHD", "CLF", "CHF"} {
+		c, _ := LookupCurrency(code)
+		s, _ := c.Format(x, &MoneyFormat{Locale: &LocaleEN})
+		fmt.Println(s)
+	}
+	eur, _ := LookupCurrency("eur")
+	fmt.Println(eur.Format(x, &MoneyFormat{Locale: &LocaleDE, After: true, Space: true}))
+	fmt.Println(eur.Format(x, &MoneyFormat{Code: true, Space: true, Mode: RoundDown}))
+
+	// Output:
+	// -$1,234.50
+	// -¥1,235
+	// -BHD1,234.500
+	// -CLF1,234.5000
+	// -CHF1,234.50
+	// -1.234,50 € true
+	// -EUR 1234.50 true
+}
+
+func ExampleParser_ParseMoney() {
+	for _, tc := range []struct {
+		Parser Parser
+		Input  string
+	}{
+		{Parser{Locale: &LocaleEN}, "$1,234.50"},
+		{Parser{Locale: &LocaleDE}, "€ 12,50"},
+		{Parser{}, "USD 99.99"},
+		{Parser{}, "-£5"},
+		{Parser{}, "99.999 bhd"},
+		{Parser{Locale: &LocaleDE}, "-12,50 €"},
+		{Parser{}, "12.50"},
+	} {
+		x, c, err := tc.Parser.ParseMoney(tc.Input, nil)
+		fmt.Println(x, c.Code, err)
+	}
+
+	// Output:
+	// 1234.5 USD <nil>
+	// 12.5 EUR <nil>
+	// 99.99 USD <nil>
+	// -5 GBP <nil>
+	// 99.999 BHD <nil>
+	// -12.5 EUR <nil>
+	// 0  round: unknown currency
+}
+
+func TestParser_ParseMoney(t *testing.T) {
+	usd, _ := LookupCurrency("USD")
+	for _, tc := range []struct {
+		Input string
+		Value string
+		Err   string
+	}{
+		{"5", "5", ""},
+		{"$5", "5", ""},
+		{"-$5", "-5", ""},
+		{"$-5", "-5", ""},
+		{"+ $ 5", "5", ""},
+		{"5$", "5", ""},
+		{"usd5", "5", ""},
+		{"5 USD", "5", ""},
+		{" -USD 1,000.005 ", "-1000.005", ""},
+		{"-$-5", "", `round: parsing "-$-5": invalid syntax at offset 2`},
+		{"$", "", `round: parsing "$": invalid syntax at offset 1`},
+		{"€5", "", `round: parsing "€5": invalid syntax at offset 0`},
+		{"$5x", "", `round: parsing "$5x": invalid syntax at offset 2`},
+		{"", "", `round: parsing "": empty input at offset 0`},
+	} {
+		x, c, err := Parser{}.ParseMoney(tc.Input, &usd)
+		if err != nil {
+			if err.Error() != tc.Err {
+				t.Errorf("%q: unexpected error: %v", tc.Input, err)
+			}
+			continue
+		}
+		if tc.Err != "" || x.String() != tc.Value || c != usd {
+			t.Errorf("%q: unexpected result: %s %v", tc.Input, x, c)
+		}
+	}
+	if _, _, err := (Parser{}).ParseMoney("5 XYZ", nil); err != ErrCurrency {
+		t.Error(err)
+	}
+	if _, _, err := (Parser{}).ParseMoney("JPY x", nil); !errors.Is(err, ErrSyntax) {
+		t.Error(err)
+	}
+	if x, c, err := (Parser{}).ParseMoney("¥500", nil); err != nil || c.Code != "JPY" || x.String() != "500" {
+		t.Error(x, c, err)
+	}
+	// the symbol may be empty, and the code is optional
+	xts := Currency{Code: "XTS", MinorUnits: 2}
+	for _, s := range []string{"1.5 XTS", "1.5"} {
+		if x, c, err := (Parser{}).ParseMoney(s, &xts); err != nil || c != xts || x.String() != "1.5" {
+			t.Error(s, x, c, err)
+		}
+	}
+}
+
+func TestCurrency_roundTrip(t *testing.T) {
+	for code := range currencyMinorUnits {
+		c, ok := LookupCurrency(code)
+		if !ok || c.Code != code {
+			t.Fatal(code)
+		}
+		for _, f := range []*MoneyFormat{nil, {Locale: &LocaleEN}, {Locale: &LocaleDE, After: true, Space: true}, {Code: true}} {
+			for _, s := range []string{"0", "-0.001", "1", "-1234567.891", "0.12345"} {
+				x, _ := ParseNumber(s)
+				formatted, ok := c.Format(x, f)
+				if !ok {
+					t.Fatal(code, s)
+				}
+				p := Parser{}
+				if f != nil {
+					p.Locale = f.Locale
+				}
+				y, d, err := p.ParseMoney(formatted, nil)
+				if err != nil || d.Code != code && d.Symbol != c.Symbol {
+					t.Fatal(code, s, formatted, y, d, err)
+				}
+				r, _ := c.Round(x, RoundHalfAwayFromZero)
+				if y.Cmp(r) != 0 {
+					t.Error(code, s, formatted, y)
+				}
+			}
+		}
+	}
+}
+
+func TestCurrency_Format_invalid(t *testing.T) {
+	usd, _ := LookupCurrency("USD")
+	if s, ok := usd.Format(Inf(1), nil); s != "" || ok {
+		t.Error(s, ok)
+	}
+	if s, ok := usd.Format(Number{}, &MoneyFormat{Mode: RoundingMode(-1)}); s != "" || ok {
+		t.Error(s, ok)
+	}
+	z, _ := Parser{Specials: true}.ParseNumber("-0")
+	if s, ok := usd.Format(z, nil); s != "$0.00" || !ok {
+		t.Error(s, ok)
+	}
+	if _, ok := LookupCurrency("XYZ"); ok {
+		t.Error(ok)
+	}
+	if c := (Currency{Code: "ABC", MinorUnits: 1}); c.Code != "ABC" {
+		t.Error(c)
+	} else if s, _ := c.Format(Number{}, &MoneyFormat{After: true}); s != "0.0ABC" {
+		t.Error(s)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var (
+	// ErrEmpty is matched (using errors.Is) by any ParseError of kind ParseErrorEmpty.
+	ErrEmpty = errors.New("empty input")
+
+	// ErrSyntax is matched (using errors.Is) by any ParseError of kind ParseErrorSyntax.
+	ErrSyntax = errors.New("invalid syntax")
+
+	// ErrExponentRange is matched (using errors.Is) by any ParseError of kind ParseErrorExponentRange.
+	ErrExponentRange = errors.New("exponent out of range")
+
+	// ErrGrouping is matched (using errors.Is) by any ParseError of kind ParseErrorGrouping.
+	ErrGrouping = errors.New("invalid grouping")
+
+	// ErrLimit is matched (using errors.Is) by any ParseError of kind ParseErrorLimit, and is wrapped by any other
+	// errors caused by exceeding Limits.
+	ErrLimit = errors.New("limit exceeded")
+
+	// ErrDivisionByZero is returned by operations that would divide by zero.
+	ErrDivisionByZero = errors.New("round: division by zero")
+
+	// ErrRoundingMode is returned by operations given a RoundingMode that is not one of the defined constants.
+	ErrRoundingMode = errors.New("round: invalid rounding mode")
+
+	// ErrSignificantDigits is returned by operations given a number of significant digits that is less than 1.
+	ErrSignificantDigits = errors.New("round: invalid number of significant digits")
+
+	// ErrOverflow is returned by the integer conversions, if the value is out of range for the target type.
+	ErrOverflow = errors.New("round: integer overflow")
+
+	// ErrNotIntegral is returned by the integer conversions, if the value has a non-zero fractional component.
+	ErrNotIntegral = errors.New("round: value is not an integer")
+
+	// ErrNotFinite is returned by conversions to types that can't represent an infinity or NaN.
+	ErrNotFinite = errors.New("round: value is not finite")
+
+	// ErrCurrency is returned by Parser.ParseMoney if the currency could not be detected.
+	ErrCurrency = errors.New("round: unknown currency")
+
+	// ErrWeight is returned by Number.Allocate if any of the weights are negative, or not finite.
+	ErrWeight = errors.New("round: invalid weight")
+
+	// ErrAllocation is returned by Number.Allocate given an Allocation that is not one of the defined constants.
+	ErrAllocation = errors.New("round: invalid allocation method")
+)
+
+// ParseErrorKind identifies the cause of a ParseError.
+type ParseErrorKind int
+
+const (
+	// ParseErrorEmpty indicates the input contained nothing to parse, after stripping whitespace and separators.
+	ParseErrorEmpty ParseErrorKind = iota + 1
+
+	// ParseErrorSyntax indicates the input did not match the expected format.
+	ParseErrorSyntax
+
+	// ParseErrorExponentRange indicates the exponential component was well-formed, but could not fit in an int.
+	ParseErrorExponentRange
+
+	// ParseErrorLimit indicates the input exceeded the configured Limits.
+	ParseErrorLimit
+
+	// ParseErrorGrouping indicates a grouping separator was in an invalid position, see Locale.Grouping.
+	ParseErrorGrouping
+)
+
+// String returns a short description of the kind.
+func (k ParseErrorKind) String() string {
+	if err := k.err(); err != nil {
+		return err.Error()
+	}
+	return "ParseErrorKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// err returns the sentinel error for the kind, or nil if the kind is not valid.
+func (k ParseErrorKind) err() error {
+	switch k {
+	case ParseErrorEmpty:
+		return ErrEmpty
+	case ParseErrorSyntax:
+		return ErrSyntax
+	case ParseErrorExponentRange:
+		return ErrExponentRange
+	case ParseErrorLimit:
+		return ErrLimit
+	case ParseErrorGrouping:
+		return ErrGrouping
+	default:
+		return nil
+	}
+}
+
+// ParseError describes a failure to parse a number, as returned by ParseStringErr and friends, note that it will
+// match the sentinel error for its kind using errors.Is (e.g. ErrSyntax), and will unwrap to Err.
+type ParseError struct {
+	// Input is the value that was being parsed.
+	Input string
+
+	// Offset is the byte offset within Input where the problem was detected, which will be len(Input) if the input
+	// ended unexpectedly.
+	Offset int
+
+	// Kind identifies the cause of the error.
+	Kind ParseErrorKind
+
+	// Err is the underlying error, if any, e.g. from strconv.Atoi.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("round: parsing %q: %s at offset %d", e.Input, e.Kind, e.Offset)
+}
+
+// Unwrap returns the underlying error, if any.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true if target is the sentinel error for the kind of e.
+func (e *ParseError) Is(target error) bool {
+	return target != nil && target == e.Kind.err()
+}
+
+// parseErrorWithin converts err, from parsing the part of s starting at offset, to a *ParseError for s, where an
+// empty part is a syntax error (unless s is also empty), note that any other type of error is returned as-is.
+func parseErrorWithin(err error, s string, offset int) error {
+	e, ok := err.(*ParseError)
+	if !ok {
+		return err
+	}
+	kind := e.Kind
+	if kind == ParseErrorEmpty && len(s) != 0 {
+		// e.g. "(3)" for ParseRepeating, which isn't empty, it's missing the prefix
+		kind = ParseErrorSyntax
+	}
+	return &ParseError{Input: s, Offset: offset + e.Offset, Kind: kind, Err: e.Err}
+}
@@ -0,0 +1,57 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"math"
+	"math/big"
+)
+
+// StringExact is like String, but formats float32 and float64 values as their exact decimal expansion, i.e. all the
+// digits of mantissa x 2 ^ exponent, which is always finite, e.g. 0.1 is 0.1000000000000000055511151231257827...
+// (55 digits in total), the special values are formatted the same as String.
+//
+// This allows rounding to be performed against the value actually stored, e.g. DecimalExact(2.675, 2) is "2.67",
+// as the float64 is really 2.67499999999999982236431605997495353221893310546875, see also StringShortest.
+//
+// NOTE: the expansion can be long, up to 767 significant digits for a float64 subnormal.
+func StringExact(v interface{}) string {
+	return formatValue(v, formatFloatExact)
+}
+
+// ParseExact is like Parse, but uses StringExact.
+func ParseExact(v interface{}) (signbit bool, integer string, fractional string, exponential int, ok bool) {
+	return ParseString(StringExact(v))
+}
+
+// DecimalExact is like Decimal, but uses StringExact.
+func DecimalExact(v interface{}, n int) (string, bool) {
+	return decimalMode(v, StringExact, n, RoundHalfAwayFromZero)
+}
+
+// DecimalExactMode is like DecimalMode, but uses StringExact.
+func DecimalExactMode(v interface{}, n int, mode RoundingMode) (string, bool) {
+	return decimalMode(v, StringExact, n, mode)
+}
+
+// formatFloatExact is the float formatter for StringExact.
+func formatFloatExact(f float64, bitSize int) string {
+	if math.IsInf(f, 0) || math.IsNaN(f) || f == 0 {
+		return formatFloat(f, bitSize)
+	}
+	return NewNumberBigFloat(big.NewFloat(f)).String()
+}
@@ -0,0 +1,92 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func ExampleStringExact() {
+	fmt.Println(StringExact(0.1))
+	fmt.Println(StringExact(float32(0.1)))
+	fmt.Println(StringExact(1e23))
+	fmt.Println(StringExact(math.Inf(-1)))
+
+	// Output:
+	// 0.1000000000000000055511151231257827021181583404541015625
+	// 0.100000001490116119384765625
+	// 99999999999999991611392
+	// -Inf
+}
+
+func ExampleDecimalExact() {
+	for _, v := range []float64{2.675, 1.005, 0.125, 0.1} {
+		a, _ := Decimal(v, 2)
+		b, _ := DecimalShortest(v, 2)
+		c, _ := DecimalExact(v, 2)
+		fmt.Println(a, b, c)
+	}
+
+	// the difference is only visible with enough digits
+	fmt.Println(Decimal(0.1, 20))
+	fmt.Println(DecimalExact(0.1, 20))
+
+	// Output:
+	// 2.67 2.68 2.67
+	// 1 1.01 1
+	// 0.13 0.13 0.13
+	// 0.1 0.1 0.1
+	// 0.10000000000000001 true
+	// 0.10000000000000000555 true
+}
+
+func TestStringExact(t *testing.T) {
+	for _, f := range []float64{1, -1, 0.1, -1.0 / 3, math.Pi, math.MaxFloat64, math.SmallestNonzeroFloat64, -2.5e-300, 123456789.123, 0x1p-1022} {
+		x, err := ParseNumber(StringExact(f))
+		if err != nil {
+			t.Fatal(f, err)
+		}
+		// the expansion must be exact, not just round trip
+		r, _ := x.BigRat()
+		if e, _ := new(big.Float).SetFloat64(f).Rat(nil); r.Cmp(e) != 0 {
+			t.Error(f, x)
+		}
+		if v, err := x.Float64(); err != nil || v != f {
+			t.Error(f, v, err)
+		}
+	}
+	for _, v := range []interface{}{0.0, math.Copysign(0, -1), math.NaN(), math.Inf(1), float32(math.Inf(-1)), "1.5", 7} {
+		if a, b := StringExact(v), String(v); a != b {
+			t.Error(v, a, b)
+		}
+	}
+	if s := StringExact(math.SmallestNonzeroFloat64); len(s) != 1076 {
+		t.Error(len(s))
+	}
+	if s, ok := DecimalExactMode(0.1, 17, RoundCeiling); s != "0.10000000000000001" || !ok {
+		t.Error(s, ok)
+	}
+	if s, ok := DecimalExactMode(0.1, 17, RoundFloor); s != "0.1" || !ok {
+		t.Error(s, ok)
+	}
+	if s, ok := Join(Runes(ParseExact(float32(0.5)))); s != "0.5" || !ok {
+		t.Error(s, ok)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"strconv"
+)
+
+const (
+	// MarkerE is the exponent marker for e notation, e.g. 1.5e-7.
+	MarkerE = `e`
+
+	// MarkerUpperE is the exponent marker for E notation, e.g. 1.5E-7.
+	MarkerUpperE = `E`
+
+	// MarkerX10 is the exponent marker for x10^ notation, e.g. 1.5x10^-7.
+	MarkerX10 = `x10^`
+
+	// MarkerStar10 is the exponent marker for *10^ notation, e.g. 1.5*10^-7.
+	MarkerStar10 = `*10^`
+)
+
+// JoinScientific returns a func like Join, that formats as normalised scientific notation, in the format
+// [-]D[.FRACTIONAL_COMPONENT]<marker>[-]EXPONENT, where D is a single non-zero digit (or 0 for zero), and the
+// marker separates the exponent. The output can be parsed by ParseString, if the marker is one of the Marker
+// constants.
+//
+// NOTE: the output will contain all significant digits, use ApplySignificant first to limit them, and ok will be
+// false if the exponent to output is outside the range of int.
+func JoinScientific(marker string) func(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (string, bool) {
+	return func(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (string, bool) {
+		return joinExponent(marker, 1, signbit, integer, fractional, exponential, ok)
+	}
+}
+
+// JoinEngineering returns a func like JoinScientific, but which formats as engineering notation, where the
+// exponent is always a multiple of 3, with between 1 and 3 integer digits.
+func JoinEngineering(marker string) func(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (string, bool) {
+	return func(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (string, bool) {
+		return joinExponent(marker, 3, signbit, integer, fractional, exponential, ok)
+	}
+}
+
+// Scientific returns the number formatted using JoinScientific, or one of +Inf, -Inf, NaN, or -0e0, see Class, note
+// that it returns an empty string if the exponent is out of range.
+func (x Number) Scientific(marker string) string {
+	if x.Class() == ClassNegativeZero {
+		return "-0" + marker + "0"
+	}
+	if s, ok := x.specialString(); ok {
+		return s
+	}
+	s, _ := JoinScientific(marker)(x.Runes())
+	return s
+}
+
+// Engineering returns the number formatted using JoinEngineering, or one of +Inf, -Inf, NaN, or -0e0, see Class,
+// note that it returns an empty string if the exponent is out of range.
+func (x Number) Engineering(marker string) string {
+	if x.Class() == ClassNegativeZero {
+		return "-0" + marker + "0"
+	}
+	if s, ok := x.specialString(); ok {
+		return s
+	}
+	s, _ := JoinEngineering(marker)(x.Runes())
+	return s
+}
+
+// joinExponent implements JoinScientific (step=1) and JoinEngineering (step=3).
+func joinExponent(marker string, step int, signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (string, bool) {
+	if !ok {
+		return "", false
+	}
+
+	p, nonZero, inRange := leadingExponent(integer, fractional, exponential)
+	if !nonZero {
+		return "0" + marker + "0", true
+	}
+	if !inRange {
+		return "", false
+	}
+
+	// all significant digits, with any leading or trailing zeros stripped
+	digits := make([]rune, 0, len(integer)+len(fractional))
+	digits = append(digits, integer...)
+	digits = append(digits, fractional...)
+	for digits[0] == '0' {
+		digits = digits[1:]
+	}
+	for digits[len(digits)-1] == '0' {
+		digits = digits[:len(digits)-1]
+	}
+
+	// the exponent to output must be a multiple of step, rounding toward negative infinity, which determines how
+	// many digits we need before the decimal point
+	m := ((p % step) + step) % step
+	if p < minInt+m {
+		return "", false
+	}
+	e := p - m
+	n := m + 1
+	for len(digits) < n {
+		digits = append(digits, '0')
+	}
+
+	result := make([]rune, 0, len(digits)+len(marker)+22)
+	if signbit {
+		result = append(result, '-')
+	}
+	result = append(result, digits[:n]...)
+	if len(digits) > n {
+		result = append(result, '.')
+		result = append(result, digits[n:]...)
+	}
+	result = append(result, []rune(marker)...)
+	result = append(result, []rune(strconv.Itoa(e))...)
+
+	return string(result), true
+}
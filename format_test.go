@@ -0,0 +1,108 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func ExampleJoinScientific() {
+	fmt.Println(JoinScientific(MarkerE)(Runes(ParseString("1e300"))))
+	fmt.Println(JoinScientific(MarkerUpperE)(Runes(ParseString("-0.000012340"))))
+	fmt.Println(JoinScientific(MarkerX10)(Runes(ParseString("123456.789"))))
+	fmt.Println(JoinScientific(MarkerStar10)(Runes(ParseString("0"))))
+	fmt.Println(JoinScientific(MarkerE)(Runes(ParseString("invalid"))))
+
+	// combined with significant digit rounding
+	fmt.Println(JoinScientific(MarkerE)(ApplySignificant(Runes(Parse(math.Pi * 1e100)))(5)))
+
+	// Output:
+	// 1e300 true
+	// -1.234E-5 true
+	// 1.23456789x10^5 true
+	// 0*10^0 true
+	//  false
+	// 3.1416e100 true
+}
+
+func ExampleJoinEngineering() {
+	for _, s := range []string{"1", "12", "123", "1234", "0.1", "0.012", "-0.00123", "1.5e-7", "0"} {
+		fmt.Println(JoinEngineering(MarkerE)(Runes(ParseString(s))))
+	}
+
+	// Output:
+	// 1e0 true
+	// 12e0 true
+	// 123e0 true
+	// 1.234e3 true
+	// 100e-3 true
+	// 12e-3 true
+	// -1.23e-3 true
+	// 150e-9 true
+	// 0e0 true
+}
+
+func TestJoinScientific_exponentRange(t *testing.T) {
+	for _, tc := range []struct {
+		Input       string
+		Scientific  string
+		Engineering string
+	}{
+		{"1e9223372036854775807", "1e9223372036854775807", "10e9223372036854775806"},
+		{"12e9223372036854775806", "1.2e9223372036854775807", "12e9223372036854775806"},
+		{"12e9223372036854775807", "", ""},
+		{"0.12e-9223372036854775807", "1.2e-9223372036854775808", ""},
+		{"1e-9223372036854775806", "1e-9223372036854775806", "1e-9223372036854775806"},
+		{"1e-9223372036854775807", "1e-9223372036854775807", ""},
+		{"0.1e-9223372036854775808", "", ""},
+		{"0e-9223372036854775808", "0e0", "0e0"},
+	} {
+		for _, c := range []struct {
+			Join     func(bool, []rune, []rune, int, bool) (string, bool)
+			Expected string
+		}{
+			{JoinScientific(MarkerE), tc.Scientific},
+			{JoinEngineering(MarkerE), tc.Engineering},
+		} {
+			if s, ok := c.Join(Runes(ParseString(tc.Input))); s != c.Expected || ok != (c.Expected != "") {
+				t.Error(tc.Input, s, ok, "!=", c.Expected)
+			}
+		}
+	}
+}
+
+func TestJoinScientific_roundTrip(t *testing.T) {
+	for _, s := range []string{"0", "1", "-1", "10", "0.5", "-123.456e7", "0000.00010000", "99999e-99999", "1.2e999999"} {
+		x, err := ParseNumber(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, marker := range []string{MarkerE, MarkerUpperE, MarkerX10, MarkerStar10} {
+			for _, formatted := range []string{x.Scientific(marker), x.Engineering(marker)} {
+				y, err := ParseNumber(formatted)
+				if err != nil {
+					t.Fatal(s, marker, formatted, err)
+				}
+				if x.Cmp(y) != 0 {
+					t.Error(s, marker, formatted, "!=", y)
+				}
+			}
+		}
+	}
+}
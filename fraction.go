@@ -0,0 +1,105 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseFraction parses a fraction like "3/8" or "-7/16", a mixed number like "1 1/2" or "-2 3/4", or any input
+// accepted by ParseNumber, converting it to a decimal rounded to n places using mode, where exact will be false if
+// rounding changed the value, e.g. for a repeating decimal like "1/3", see also Number.Quo.
+//
+// Each component is parsed in the same way as ParseNumber, though only the whole number may have a sign (or the
+// numerator, if there is no whole number), and either a '/' or a '⁄' (U+2044 FRACTION SLASH) may separate the
+// numerator and denominator. The whole number is separated from the numerator by the last whitespace (as defined
+// by the Locale) prior to the slash, and the sign of the whole number applies to the fraction, i.e. "-1 1/2" is -1.5.
+//
+// Errors are either a *ParseError, relative to the whole input, ErrDivisionByZero, or ErrRoundingMode.
+func (p Parser) ParseFraction(s string, n int, mode RoundingMode) (x Number, exact bool, err error) {
+	if !mode.valid() {
+		return Number{}, false, ErrRoundingMode
+	}
+
+	slash := strings.IndexAny(s, "/⁄")
+	if slash < 0 {
+		if x, err = p.ParseNumber(s); err != nil {
+			return Number{}, false, err
+		}
+		r, _ := x.RoundMode(n, mode)
+		return r, r.Cmp(x) == 0, nil
+	}
+	_, size := utf8.DecodeRuneInString(s[slash:])
+
+	locale := p.locale()
+
+	// a mixed number has whitespace separating two non-empty components, prior to the slash
+	var (
+		whole    Number
+		mixed    bool
+		negative bool
+		start    int
+	)
+	left := strings.TrimLeftFunc(s[:slash], locale.space)
+	offset := slash - len(left)
+	left = strings.TrimRightFunc(left, locale.space)
+	if i := strings.LastIndexFunc(left, locale.space); i >= 0 {
+		if whole, err = p.fractionPart(s, 0, offset+i, true); err != nil {
+			return Number{}, false, err
+		}
+		_, size := utf8.DecodeRuneInString(left[i:])
+		mixed, negative, start = true, strings.HasPrefix(left, "-"), offset+i+size
+	}
+
+	num, err := p.fractionPart(s, start, slash, !mixed)
+	if err != nil {
+		return Number{}, false, err
+	}
+	den, err := p.fractionPart(s, slash+size, len(s), false)
+	if err != nil {
+		return Number{}, false, err
+	}
+
+	if mixed {
+		num = whole.Abs().Mul(den).Add(num)
+		if negative {
+			num = num.Neg()
+		}
+	}
+
+	return num.Quo(den, n, mode)
+}
+
+// fractionPart parses s[start:end] for ParseFraction, returning any error relative to s.
+func (p Parser) fractionPart(s string, start, end int, signed bool) (Number, error) {
+	part := s[start:end]
+	trimmed := strings.TrimLeftFunc(part, p.locale().space)
+	if !signed && (strings.HasPrefix(trimmed, "-") || strings.HasPrefix(trimmed, "+")) {
+		return Number{}, &ParseError{Input: s, Offset: start + len(part) - len(trimmed), Kind: ParseErrorSyntax}
+	}
+	x, err := p.ParseNumber(part)
+	if e, ok := err.(*ParseError); ok {
+		kind := e.Kind
+		if kind == ParseErrorEmpty {
+			// the input as a whole wasn't empty, we are just missing a component
+			kind = ParseErrorSyntax
+		}
+		return Number{}, &ParseError{Input: s, Offset: start + e.Offset, Kind: kind, Err: e.Err}
+	}
+	return x, err
+}
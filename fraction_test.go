@@ -0,0 +1,121 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func ExampleParser_ParseFraction() {
+	for _, s := range []string{"3/8", "1 1/2", "-7/16", "-2 3/4", "1/3", "2/3", "0.5", "22⁄7"} {
+		fmt.Println(Parser{}.ParseFraction(s, 3, RoundHalfEven))
+	}
+
+	// Output:
+	// 0.375 true <nil>
+	// 1.5 true <nil>
+	// -0.438 false <nil>
+	// -2.75 true <nil>
+	// 0.333 false <nil>
+	// 0.667 false <nil>
+	// 0.5 true <nil>
+	// 3.143 false <nil>
+}
+
+func TestParser_ParseFraction(t *testing.T) {
+	for _, tc := range []struct {
+		Input  string
+		N      int
+		Mode   RoundingMode
+		Output string
+		Exact  bool
+		Err    string
+	}{
+		{"1/2", 0, RoundHalfEven, "0", false, ""},
+		{"3/2", 0, RoundHalfEven, "2", false, ""},
+		{"1/8", 3, RoundHalfEven, "0.125", true, ""},
+		{"1/8", 2, RoundDown, "0.12", false, ""},
+		{" 3 / 8 ", 3, RoundHalfEven, "0.375", true, ""},
+		{"+3/8", 3, RoundHalfEven, "0.375", true, ""},
+		{"  -1   1/4  ", 2, RoundHalfEven, "-1.25", true, ""},
+		{"-0 1/4", 2, RoundHalfEven, "-0.25", true, ""},
+		{"1,000 1/2", 1, RoundHalfEven, "1000.5", true, ""},
+		{"1.5/0.5", 1, RoundHalfEven, "3", true, ""},
+		{"1e3/7", 2, RoundCeiling, "142.86", false, ""},
+		{"-1/3", 2, RoundFloor, "-0.34", false, ""},
+		{"0/5", 2, RoundHalfEven, "0", true, ""},
+		{"1.25", 1, RoundHalfEven, "1.2", false, ""},
+		{"1.25", 2, RoundHalfEven, "1.25", true, ""},
+		{"1/0", 2, RoundHalfEven, "", false, "round: division by zero"},
+		{"1/2", 2, RoundingMode(-1), "", false, "round: invalid rounding mode"},
+		{"/2", 2, RoundHalfEven, "", false, `round: parsing "/2": invalid syntax at offset 0`},
+		{"1/", 2, RoundHalfEven, "", false, `round: parsing "1/": invalid syntax at offset 2`},
+		{"1/-2", 2, RoundHalfEven, "", false, `round: parsing "1/-2": invalid syntax at offset 2`},
+		{"1 -1/2", 2, RoundHalfEven, "", false, `round: parsing "1 -1/2": invalid syntax at offset 2`},
+		{"1/2/3", 2, RoundHalfEven, "", false, `round: parsing "1/2/3": invalid syntax at offset 3`},
+		{"1 2 3/4", 2, RoundHalfEven, "12.75", true, ""},
+		{"a 1/2", 2, RoundHalfEven, "", false, `round: parsing "a 1/2": invalid syntax at offset 0`},
+		{"1 1x/2", 2, RoundHalfEven, "", false, `round: parsing "1 1x/2": invalid syntax at offset 3`},
+		{"", 2, RoundHalfEven, "", false, `round: parsing "": empty input at offset 0`},
+	} {
+		x, exact, err := Parser{}.ParseFraction(tc.Input, tc.N, tc.Mode)
+		if err != nil {
+			if err.Error() != tc.Err {
+				t.Errorf("%q: unexpected error: %v", tc.Input, err)
+			}
+			continue
+		}
+		if tc.Err != "" || x.String() != tc.Output || exact != tc.Exact {
+			t.Errorf("%q: unexpected result: %s %v", tc.Input, x, exact)
+		}
+	}
+}
+
+func TestParser_ParseFraction_locale(t *testing.T) {
+	// LocaleFR uses spaces for grouping, but the last space before the numerator still separates the whole number
+	x, exact, err := Parser{Locale: &LocaleFR}.ParseFraction("1 234 1/2", 1, RoundHalfEven)
+	if err != nil || !exact || x.String() != "1234.5" {
+		t.Error(x, exact, err)
+	}
+	x, exact, err = Parser{Locale: &LocaleDE}.ParseFraction("1,5/3", 1, RoundHalfEven)
+	if err != nil || !exact || x.String() != "0.5" {
+		t.Error(x, exact, err)
+	}
+	_, _, err = Parser{Limits: Limits{MaxDigits: 2}}.ParseFraction("1/123", 1, RoundHalfEven)
+	if !errors.Is(err, ErrLimit) || err.(*ParseError).Offset != 4 {
+		t.Error(err)
+	}
+}
+
+func TestParser_ParseFraction_bigRat(t *testing.T) {
+	for num := int64(-30); num <= 30; num++ {
+		for den := int64(1); den <= 30; den++ {
+			r := big.NewRat(num, den)
+			x, exact, err := Parser{}.ParseFraction(String(r), 6, RoundHalfEven)
+			if err != nil {
+				t.Fatal(r, err)
+			}
+			y, e, _ := NewNumberBigRat(r, 6, RoundHalfEven)
+			if x.Cmp(y) != 0 || exact != e {
+				t.Fatal(r, x, exact, y, e)
+			}
+		}
+	}
+}
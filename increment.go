@@ -0,0 +1,44 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+// RoundIncrement returns x rounded to a multiple of the increment m using mode, e.g. to the nearest 0.05 for cash
+// rounding, or to a tick size like 0.25 or 12.5, which is performed exactly, as Quo(m, 0, mode) then Mul(m), where
+// the sign of m is ignored.
+//
+// An error will be returned if the mode is not valid (ErrRoundingMode), if m is zero (ErrDivisionByZero), or if
+// m is an infinity or NaN (ErrNotFinite), note that the special values of x are returned unchanged.
+func (x Number) RoundIncrement(m Number, mode RoundingMode) (Number, error) {
+	if !mode.valid() {
+		return Number{}, ErrRoundingMode
+	}
+	if !m.isFinite() {
+		return Number{}, ErrNotFinite
+	}
+	if m.IsZero() {
+		return Number{}, ErrDivisionByZero
+	}
+	if x.isSpecial() {
+		return x, nil
+	}
+	m = m.Abs()
+	q, _, err := x.Quo(m, 0, mode)
+	if err != nil {
+		return Number{}, err
+	}
+	return q.Mul(m), nil
+}
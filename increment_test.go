@@ -0,0 +1,109 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleNumber_RoundIncrement() {
+	nickel, _ := ParseNumber("0.05")
+	for _, s := range []string{"1.02", "1.025", "1.03", "-1.075"} {
+		x, _ := ParseNumber(s)
+		fmt.Println(x.RoundIncrement(nickel, RoundHalfAwayFromZero))
+	}
+
+	tick, _ := ParseNumber("0.25")
+	x, _ := ParseNumber("101.37")
+	fmt.Println(x.RoundIncrement(tick, RoundFloor))
+	fmt.Println(x.RoundIncrement(tick, RoundCeiling))
+
+	// Output:
+	// 1 <nil>
+	// 1.05 <nil>
+	// 1.05 <nil>
+	// -1.1 <nil>
+	// 101.25 <nil>
+	// 101.5 <nil>
+}
+
+func TestNumber_RoundIncrement(t *testing.T) {
+	for _, tc := range []struct {
+		X, M   string
+		Mode   RoundingMode
+		Result string
+	}{
+		{"1.2345", "0.009", RoundHalfEven, "1.233"},
+		{"1.2375", "0.009", RoundUp, "1.242"},
+		{"0.0045", "0.009", RoundHalfEven, "0"},
+		{"0.0135", "0.009", RoundHalfEven, "0.018"},
+		{"0.0135", "0.009", RoundHalfDown, "0.009"},
+		{"-0.0135", "0.009", RoundHalfUp, "-0.009"},
+		{"-0.0135", "0.009", RoundHalfDown, "-0.018"},
+		{"18.75", "12.5", RoundHalfEven, "25"},
+		{"31.25", "12.5", RoundHalfEven, "25"},
+		{"31.25", "12.5", RoundHalfTowardZero, "25"},
+		{"31.25", "-12.5", RoundHalfAwayFromZero, "37.5"},
+		{"124", "50", RoundHalfEven, "100"},
+		{"125", "50", RoundHalfEven, "100"},
+		{"175", "50", RoundHalfEven, "200"},
+		{"1e10", "3", RoundDown, "9999999999"},
+		{"1e-10", "1e-12", RoundHalfEven, "0.0000000001"},
+		{"1.23", "0.01", RoundHalfEven, "1.23"},
+		{"1.23", "1", Round05Up, "1"},
+		{"0", "0.05", RoundCeiling, "0"},
+	} {
+		x, _ := ParseNumber(tc.X)
+		m, _ := ParseNumber(tc.M)
+		r, err := x.RoundIncrement(m, tc.Mode)
+		if err != nil || r.String() != tc.Result {
+			t.Error(tc.X, tc.M, tc.Mode, r, err)
+		}
+		// the result must be a multiple of m
+		if q, exact, err := r.Quo(m, 0, RoundDown); err != nil || !exact {
+			t.Error(tc.X, tc.M, q, exact, err)
+		}
+	}
+}
+
+func TestNumber_RoundIncrement_errors(t *testing.T) {
+	one, _ := ParseNumber("1")
+	if _, err := one.RoundIncrement(one, RoundingMode(-1)); err != ErrRoundingMode {
+		t.Error(err)
+	}
+	if _, err := one.RoundIncrement(Number{}, RoundHalfEven); err != ErrDivisionByZero {
+		t.Error(err)
+	}
+	huge, _ := ParseNumber("1e9223372036854775807")
+	tiny, _ := ParseNumber("1e-9223372036854775808")
+	if _, err := huge.RoundIncrement(tiny, RoundHalfEven); !errors.Is(err, ErrLimit) {
+		t.Error(err)
+	}
+	if _, err := one.RoundIncrement(Inf(1), RoundHalfEven); err != ErrNotFinite {
+		t.Error(err)
+	}
+	if _, err := one.RoundIncrement(NaN(), RoundHalfEven); err != ErrNotFinite {
+		t.Error(err)
+	}
+	for _, x := range []Number{Inf(1), Inf(-1), NaN()} {
+		if r, err := x.RoundIncrement(one, RoundHalfEven); err != nil || r.Class() != x.Class() {
+			t.Error(x, r, err)
+		}
+	}
+}
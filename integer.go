@@ -0,0 +1,199 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// Int64 can be used with Runes(Parse(...)) to convert to int64 without any loss of precision, returning
+// ErrNotIntegral if the value has a non-zero fractional component, or ErrOverflow if it doesn't fit, note it will
+// return an error if ok is false.
+//
+// Rounding to an integer first is as simple as Int64(Apply(Runes(Parse(v)))(0)), or use ApplyMode.
+func Int64(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (int64, error) {
+	return intN("round.Int64", 64, signbit, integer, fractional, exponential, ok)
+}
+
+// Int32 is like Int64, but converts to int32.
+func Int32(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (int32, error) {
+	v, err := intN("round.Int32", 32, signbit, integer, fractional, exponential, ok)
+	return int32(v), err
+}
+
+// Int is like Int64, but converts to int.
+func Int(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (int, error) {
+	v, err := intN("round.Int", bits.UintSize, signbit, integer, fractional, exponential, ok)
+	return int(v), err
+}
+
+// Uint64 is like Int64, but converts to uint64, note that any negative (non-zero) value will return ErrOverflow.
+func Uint64(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (uint64, error) {
+	return uintN("round.Uint64", math.MaxUint64, signbit, integer, fractional, exponential, ok)
+}
+
+// Uint32 is like Uint64, but converts to uint32.
+func Uint32(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (uint32, error) {
+	v, err := uintN("round.Uint32", math.MaxUint32, signbit, integer, fractional, exponential, ok)
+	return uint32(v), err
+}
+
+// Uint is like Uint64, but converts to uint.
+func Uint(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (uint, error) {
+	v, err := uintN("round.Uint", math.MaxUint64>>(64-bits.UintSize), signbit, integer, fractional, exponential, ok)
+	return uint(v), err
+}
+
+// BigInt is like Int64, but converts to a *big.Int, and so can't overflow (unless the number of digits would exceed
+// the max int), note that the number of digits is otherwise unbounded, so use Limits to restrict untrusted input.
+func BigInt(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (*big.Int, error) {
+	digits, err := integerDigits("round.BigInt", -1, signbit, integer, fractional, exponential, ok)
+	if err != nil {
+		return nil, err
+	}
+	v := new(big.Int)
+	if len(digits) != 0 {
+		v.SetString(string(digits), 10)
+		if signbit {
+			v.Neg(v)
+		}
+	}
+	return v, nil
+}
+
+// Int64 converts the number to an int64, see the Int64 function, note that infinities will return ErrOverflow,
+// and NaN will return ErrNotIntegral.
+func (x Number) Int64() (int64, error) {
+	if err := x.integerError(); err != nil {
+		return 0, err
+	}
+	return Int64(x.Runes())
+}
+
+// Uint64 converts the number to a uint64, see the Uint64 function, and Number.Int64 for the special values.
+func (x Number) Uint64() (uint64, error) {
+	if err := x.integerError(); err != nil {
+		return 0, err
+	}
+	return Uint64(x.Runes())
+}
+
+// BigInt converts the number to a *big.Int, see the BigInt function, and Number.Int64 for the special values.
+func (x Number) BigInt() (*big.Int, error) {
+	if err := x.integerError(); err != nil {
+		return nil, err
+	}
+	return BigInt(x.Runes())
+}
+
+// integerError returns the error for converting a non-finite number to an integer, or nil.
+func (x Number) integerError() error {
+	switch {
+	case x.IsNaN():
+		return ErrNotIntegral
+	case x.IsInf(0):
+		return ErrOverflow
+	default:
+		return nil
+	}
+}
+
+// intN implements the signed integer conversions, for a size in bits, returning the value as an int64.
+func intN(name string, size uint, signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (int64, error) {
+	max := uint64(1)<<(size-1) - 1
+	if signbit {
+		// the magnitude of the minimum value is one greater than the maximum value
+		max++
+	}
+	v, err := uintN(name, max, false, integer, fractional, exponential, ok)
+	if err != nil {
+		return 0, err
+	}
+	if signbit {
+		return -int64(v), nil
+	}
+	return int64(v), nil
+}
+
+// uintN implements the unsigned integer conversions, for values up to max.
+func uintN(name string, max uint64, signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (uint64, error) {
+	// 20 is the number of digits in math.MaxUint64, anything with more digits will overflow
+	digits, err := integerDigits(name, 20, signbit, integer, fractional, exponential, ok)
+	if err != nil {
+		return 0, err
+	}
+	if signbit && len(digits) != 0 {
+		return 0, ErrOverflow
+	}
+	var v uint64
+	for _, d := range digits {
+		if v > (max-uint64(d-'0'))/10 {
+			return 0, ErrOverflow
+		}
+		v = v*10 + uint64(d-'0')
+	}
+	return v, nil
+}
+
+// integerDigits returns the digits of an integral value, with any leading zeros stripped (an empty result
+// representing zero), or ErrNotIntegral, or ErrOverflow if there would be more than max digits, where max < 0 means
+// as many digits as will fit in an int.
+func integerDigits(name string, max int, signbit bool, integer []rune, fractional []rune, exponential int, ok bool) ([]rune, error) {
+	if !ok {
+		return nil, errors.New(name + " failed to parse string")
+	}
+
+	p, nonZero, inRange := leadingExponent(integer, fractional, exponential)
+	if !nonZero {
+		return nil, nil
+	}
+
+	// the least significant non-zero digit must be in the 10 ^ 0 position or above, it's checked before the number
+	// of digits, as it's the reason rounding first would succeed (the comparisons are arranged to avoid overflow)
+	for i := len(fractional) - 1; i >= 0; i-- {
+		if fractional[i] != '0' {
+			if exponential < i+1 {
+				return nil, ErrNotIntegral
+			}
+			break
+		}
+	}
+	for i := len(integer) - 1; i >= 0; i-- {
+		if integer[i] != '0' {
+			if exponential < -(len(integer) - 1 - i) {
+				return nil, ErrNotIntegral
+			}
+			break
+		}
+	}
+
+	if max < 0 {
+		max = maxInt
+	}
+	if !inRange || p >= max {
+		return nil, ErrOverflow
+	}
+
+	digits, _ := shift(integer, fractional, exponential)
+	for len(digits) != 0 && digits[0] == '0' {
+		digits = digits[1:]
+	}
+	return digits, nil
+}
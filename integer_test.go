@@ -0,0 +1,182 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func ExampleInt64() {
+	fmt.Println(Int64(Runes(Parse("9007199254740993"))))
+	fmt.Println(Int64(Runes(Parse("-9.223372036854775808e18"))))
+	fmt.Println(Int64(Runes(Parse("9223372036854775808"))))
+	fmt.Println(Int64(Runes(Parse("12.5"))))
+
+	// round first, using Apply or ApplyMode
+	fmt.Println(Int64(Apply(Runes(Parse("12.5")))(0)))
+	fmt.Println(Int64(ApplyMode(Runes(Parse("12.5")))(0, RoundHalfEven)))
+
+	// Output:
+	// 9007199254740993 <nil>
+	// -9223372036854775808 <nil>
+	// 0 round: integer overflow
+	// 0 round: value is not an integer
+	// 13 <nil>
+	// 12 <nil>
+}
+
+func ExampleBigInt() {
+	fmt.Println(BigInt(Runes(Parse("-1.23456789e30"))))
+	fmt.Println(BigInt(Runes(Parse("1e-1"))))
+
+	// Output:
+	// -1234567890000000000000000000000 <nil>
+	// <nil> round: value is not an integer
+}
+
+func TestIntegerConversions(t *testing.T) {
+	for _, tc := range []struct {
+		Input  string
+		Int64  string
+		Int32  string
+		Uint64 string
+		Uint32 string
+		BigInt string
+	}{
+		{"0", "0", "0", "0", "0", "0"},
+		{"-0.000e50", "0", "0", "0", "0", "0"},
+		{"1", "1", "1", "1", "1", "1"},
+		{"-1", "-1", "-1", ErrOverflow.Error(), ErrOverflow.Error(), "-1"},
+		{"0.1", ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error()},
+		{"12.3e1", "123", "123", "123", "123", "123"},
+		{"12.34e1", ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error()},
+		{"1200e-2", "12", "12", "12", "12", "12"},
+		{"1201e-2", ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error()},
+		{"2147483647", "2147483647", "2147483647", "2147483647", "2147483647", "2147483647"},
+		{"2147483648", "2147483648", ErrOverflow.Error(), "2147483648", "2147483648", "2147483648"},
+		{"-2147483648", "-2147483648", "-2147483648", ErrOverflow.Error(), ErrOverflow.Error(), "-2147483648"},
+		{"-2147483649", "-2147483649", ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), "-2147483649"},
+		{"4294967295", "4294967295", ErrOverflow.Error(), "4294967295", "4294967295", "4294967295"},
+		{"4294967296", "4294967296", ErrOverflow.Error(), "4294967296", ErrOverflow.Error(), "4294967296"},
+		{"9223372036854775807", "9223372036854775807", ErrOverflow.Error(), "9223372036854775807", ErrOverflow.Error(), "9223372036854775807"},
+		{"-9223372036854775808", "-9223372036854775808", ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), "-9223372036854775808"},
+		{"-9223372036854775809", ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), "-9223372036854775809"},
+		{"18446744073709551615", ErrOverflow.Error(), ErrOverflow.Error(), "18446744073709551615", ErrOverflow.Error(), "18446744073709551615"},
+		{"18446744073709551616", ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), "18446744073709551616"},
+		{"99999999999999999999", ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), "99999999999999999999"},
+		{"1e100", ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), "1" + fmt.Sprintf("%0100d", 0)},
+		{"1.5e100", ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), "15" + fmt.Sprintf("%099d", 0)},
+		{"1e-100", ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error()},
+		{"0.5e-9223372036854775808", ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error()},
+		{"5e-9223372036854775808", ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error()},
+		{"50e-9223372036854775808", ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error(), ErrNotIntegral.Error()},
+		{"0e-9223372036854775808", "0", "0", "0", "0", "0"},
+		{"1e9223372036854775807", ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error()},
+		{"12e9223372036854775807", ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error()},
+		{"-1.5e9223372036854775807", ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error(), ErrOverflow.Error()},
+		{"invalid", "round.Int64 failed to parse string", "round.Int32 failed to parse string", "round.Uint64 failed to parse string", "round.Uint32 failed to parse string", "round.BigInt failed to parse string"},
+	} {
+		result := func(v interface{}, err error) string {
+			if err != nil {
+				return err.Error()
+			}
+			return fmt.Sprint(v)
+		}
+		if s := result(Int64(Runes(ParseString(tc.Input)))); s != tc.Int64 {
+			t.Error(tc.Input, "Int64", s)
+		}
+		if s := result(Int32(Runes(ParseString(tc.Input)))); s != tc.Int32 {
+			t.Error(tc.Input, "Int32", s)
+		}
+		if s := result(Uint64(Runes(ParseString(tc.Input)))); s != tc.Uint64 {
+			t.Error(tc.Input, "Uint64", s)
+		}
+		if s := result(Uint32(Runes(ParseString(tc.Input)))); s != tc.Uint32 {
+			t.Error(tc.Input, "Uint32", s)
+		}
+		if s := result(BigInt(Runes(ParseString(tc.Input)))); s != tc.BigInt {
+			t.Error(tc.Input, "BigInt", s)
+		}
+		x, err := ParseNumber(tc.Input)
+		if err != nil {
+			continue
+		}
+		if s := result(x.Int64()); s != tc.Int64 {
+			t.Error(tc.Input, "Number.Int64", s)
+		}
+		if s := result(x.Uint64()); s != tc.Uint64 {
+			t.Error(tc.Input, "Number.Uint64", s)
+		}
+		if s := result(x.BigInt()); s != tc.BigInt {
+			t.Error(tc.Input, "Number.BigInt", s)
+		}
+	}
+}
+
+func TestBigInt_leadingZeros(t *testing.T) {
+	if v, err := BigInt(true, []rune("0012"), []rune("30"), 1, true); err != nil || v.String() != "-123" {
+		t.Error(v, err)
+	}
+}
+
+func TestInt_native(t *testing.T) {
+	for _, v := range []int64{math.MinInt64, math.MinInt32, -1, 0, 1, math.MaxInt32, math.MaxInt64} {
+		if int64(int(v)) != v {
+			continue
+		}
+		if r, err := Int(Runes(Parse(v))); err != nil || int64(r) != v {
+			t.Error(v, r, err)
+		}
+	}
+	if _, err := Int(Runes(Parse("1" + strconv.FormatUint(math.MaxUint64, 10)))); err != ErrOverflow {
+		t.Error(err)
+	}
+	if r, err := Uint(Runes(Parse(uint(math.MaxUint32)))); err != nil || r != math.MaxUint32 {
+		t.Error(r, err)
+	}
+	if _, err := Uint(Runes(Parse(-1))); err != ErrOverflow {
+		t.Error(err)
+	}
+}
+
+func TestNumber_integerSpecials(t *testing.T) {
+	for _, tc := range []struct {
+		X   Number
+		Err error
+	}{
+		{Inf(1), ErrOverflow},
+		{Inf(-1), ErrOverflow},
+		{NaN(), ErrNotIntegral},
+	} {
+		if _, err := tc.X.Int64(); err != tc.Err {
+			t.Error(tc.X, err)
+		}
+		if _, err := tc.X.Uint64(); err != tc.Err {
+			t.Error(tc.X, err)
+		}
+		if _, err := tc.X.BigInt(); err != tc.Err {
+			t.Error(tc.X, err)
+		}
+	}
+	z, _ := Parser{Specials: true}.ParseNumber("-0")
+	if v, err := z.Uint64(); v != 0 || err != nil {
+		t.Error(v, err)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Limits restricts the size of numbers, in order to bound the time and memory used to process untrusted input,
+// where a zero (or negative) value for any field means no limit. See Parser and Limits.Join.
+//
+// NOTE: Apply and friends use time and memory proportional to the number of digits, and don't need limits, but
+// Join (and anything that calls it) needs to expand the exponential, e.g. 1e999999999 is a billion digits long.
+type Limits struct {
+	// MaxExponent is the maximum absolute value of the parsed exponential component.
+	MaxExponent int
+
+	// MaxDigits is the maximum number of parsed digits, including any leading or trailing zeros.
+	MaxDigits int
+
+	// MaxLength is the maximum length of the output of Join, in runes.
+	MaxLength int
+}
+
+// Join is like the Join function, but returns an error wrapping ErrLimit if the output would exceed MaxLength (or
+// the max int), which is checked before building the output.
+func (l Limits) Join(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (string, error) {
+	if !ok {
+		return "", errors.New("round.Limits.Join failed to parse string")
+	}
+	n := joinLength(signbit, integer, fractional, exponential)
+	if l.MaxLength > 0 && n > l.MaxLength {
+		return "", fmt.Errorf("round: output length %d exceeds %d: %w", n, l.MaxLength, ErrLimit)
+	}
+	if n == maxInt {
+		return "", fmt.Errorf("round: output length exceeds the max int: %w", ErrLimit)
+	}
+	s, _ := Join(signbit, integer, fractional, exponential, ok)
+	return s, nil
+}
+
+// checkExponent returns false if the exponential exceeds MaxExponent.
+func (l Limits) checkExponent(exponential int) bool {
+	return l.MaxExponent <= 0 || (exponential <= l.MaxExponent && exponential >= -l.MaxExponent)
+}
+
+// joinLength returns the length of the output of Join, without building it, saturating at the max int.
+func joinLength(signbit bool, integer []rune, fractional []rune, exponential int) int {
+	// find the range of significant digits, as positions relative to the decimal point, where the digit at position
+	// i represents 10 ^ i, so that we can find the length once the exponential is applied
+	hi, lo := 0, 0
+	nonZero := false
+	for i, r := range integer {
+		if r != '0' {
+			hi, nonZero = len(integer)-1-i, true
+			break
+		}
+	}
+	if !nonZero {
+		for i, r := range fractional {
+			if r != '0' {
+				hi, nonZero = -(i + 1), true
+				break
+			}
+		}
+	}
+	if !nonZero {
+		return 1
+	}
+	for i := len(fractional) - 1; i >= 0; i-- {
+		if fractional[i] != '0' {
+			lo = -(i + 1)
+			break
+		}
+	}
+	if lo == 0 {
+		for i := len(integer) - 1; i >= 0; i-- {
+			if integer[i] != '0' {
+				lo = len(integer) - 1 - i
+				break
+			}
+		}
+	}
+
+	// widen the range of digits to include the (implied) decimal point, since those zeros will be output
+	hi, lo = addSaturating(hi, exponential), addSaturating(lo, exponential)
+	if hi < 0 {
+		hi = 0
+	}
+	if lo > 0 {
+		lo = 0
+	}
+
+	n := addSaturating(hi, -(lo + 1))
+	n = addSaturating(n, 2)
+	if lo < 0 {
+		n = addSaturating(n, 1)
+	}
+	if signbit {
+		n = addSaturating(n, 1)
+	}
+	return n
+}
+
+const (
+	maxInt = int(^uint(0) >> 1)
+	minInt = -maxInt - 1
+)
+
+// addSaturating returns a+b, clamped to the range of int.
+func addSaturating(a, b int) int {
+	if b > 0 && a > maxInt-b {
+		return maxInt
+	}
+	if b < 0 && a < minInt-b {
+		return minInt
+	}
+	return a + b
+}
+
+// subSaturating returns a-b, clamped to the range of int.
+func subSaturating(a, b int) int {
+	if b == minInt {
+		if a >= 0 {
+			return maxInt
+		}
+		return a - b
+	}
+	return addSaturating(a, -b)
+}
@@ -0,0 +1,167 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func ExampleLimits() {
+	p := Parser{Limits: Limits{MaxExponent: 400, MaxDigits: 20}}
+
+	fmt.Println(p.ParseString("1.5e308"))
+	fmt.Println(p.ParseString("1e999999999"))
+	fmt.Println(p.ParseString("1.00000000000000000001"))
+
+	_, err := p.ParseNumber("-1e-401")
+	fmt.Println(errors.Is(err, ErrLimit))
+
+	l := Limits{MaxLength: 10}
+	fmt.Println(l.Join(Runes(ParseString("-1234.5678"))))
+	fmt.Println(l.Join(Runes(ParseString("1e10"))))
+	fmt.Println(l.Join(Runes(ParseString("x"))))
+
+	// Output:
+	// false 1 5 308 <nil>
+	// false   0 round: parsing "1e999999999": limit exceeded at offset 2
+	// false   0 round: parsing "1.00000000000000000001": limit exceeded at offset 21
+	// true
+	// -1234.5678 <nil>
+	//  round: output length 11 exceeds 10: limit exceeded
+	//  round.Limits.Join failed to parse string
+}
+
+func TestParser_maxDigits(t *testing.T) {
+	p := Parser{Limits: Limits{MaxDigits: 3}}
+	for _, s := range []string{"123", "1.23", "0.01", "1.2e12345", "999e-5"} {
+		if _, _, _, _, err := p.ParseString(s); err != nil {
+			t.Error(s, err)
+		}
+	}
+	for _, s := range []string{"1234", "1.234", "0.001", "1234e1"} {
+		if _, _, _, _, err := p.ParseString(s); !errors.Is(err, ErrLimit) {
+			t.Error(s, err)
+		}
+	}
+}
+
+func TestJoinLength(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	digits := func() []rune {
+		b := make([]rune, rng.Intn(6))
+		for i := range b {
+			b[i] = rune('0' + rng.Intn(3))
+		}
+		return b
+	}
+	for i := 0; i < 10000; i++ {
+		signbit, integer, fractional, exponential := rng.Intn(2) == 0, digits(), digits(), rng.Intn(15)-7
+		n := joinLength(signbit, integer, fractional, exponential)
+		if s, _ := Join(signbit, integer, fractional, exponential, true); n != len(s) {
+			t.Fatal(signbit, string(integer), string(fractional), exponential, s, n)
+		}
+	}
+	if n := joinLength(true, []rune("1"), []rune("1"), int(^uint(0)>>1)); n != int(^uint(0)>>1) {
+		t.Error(n)
+	}
+	if n := joinLength(false, []rune("1"), []rune("1"), -int(^uint(0)>>1)-1); n != int(^uint(0)>>1) {
+		t.Error(n)
+	}
+}
+
+func TestJoin_maxLength(t *testing.T) {
+	for _, s := range []string{"1e9223372036854775807", "-1e-9223372036854775808", "1e-9223372036854775807"} {
+		if v, ok := Join(Runes(ParseString(s))); ok || v != "" {
+			t.Error(s, v, ok)
+		}
+		if v, err := (Limits{}).Join(Runes(ParseString(s))); !errors.Is(err, ErrLimit) || v != "" {
+			t.Error(s, v, err)
+		}
+	}
+	// rounding to significant digits keeps the exponential, so the output is still too long
+	if v, ok := Join(ApplySignificant(Runes(ParseString("1e-9223372036854775808")))(3)); ok || v != "" {
+		t.Error(v, ok)
+	}
+	if v, ok := Join(ApplySignificant(Runes(ParseString("1.25e-9223372036854775808")))(2)); ok || v != "" {
+		t.Error(v, ok)
+	}
+}
+
+// TestApplyMode_reference ensures the behavior matches the original implementation, which shifted one digit at a
+// time.
+func TestApplyMode_reference(t *testing.T) {
+	reference := func(signbit bool, integer []rune, fractional []rune, exponential int, n int, mode RoundingMode) (string, bool) {
+		n += exponential
+		for n > 0 {
+			n--
+			exponential--
+			integer, fractional = moveLeft(integer, fractional)
+		}
+		for n < 0 {
+			n++
+			exponential++
+			integer, fractional = moveRight(integer, fractional)
+		}
+		if roundMode(mode, signbit, integer, fractional) {
+			integer = incrementInteger(integer)
+		}
+		return Join(signbit, integer, nil, exponential, true)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	digits := func() string {
+		b := make([]byte, rng.Intn(6))
+		for i := range b {
+			b[i] = byte('0' + rng.Intn(10))
+		}
+		return string(b)
+	}
+	for i := 0; i < 20000; i++ {
+		signbit, integer, fractional, exponential := rng.Intn(2) == 0, digits(), digits(), rng.Intn(15)-7
+		n, mode := rng.Intn(21)-10, RoundingMode(rng.Intn(int(Round05Up)+1))
+
+		a, aok := Join(ApplyMode(Runes(signbit, integer, fractional, exponential, true))(n, mode))
+		b, bok := reference(signbit, []rune(integer), []rune(fractional), exponential, n, mode)
+
+		if a != b || aok != bok {
+			t.Fatal(signbit, integer, fractional, exponential, n, mode, a, "!= expected", b)
+		}
+	}
+}
+
+func TestApplyMode_largeN(t *testing.T) {
+	signbit, integer, fractional, exponential, ok := Apply(Runes(ParseString("-1.5")))(1000000000000)
+	if s, _ := Join(signbit, integer, fractional, exponential, ok); s != "-1.5" || len(integer) != 2 || len(fractional) != 0 {
+		t.Error(s, string(integer), string(fractional), exponential)
+	}
+
+	signbit, integer, fractional, exponential, ok = ApplyMode(Runes(ParseString("-1.5e-3")))(-1000000000000, RoundFloor)
+	if signbit != true || string(integer) != "1" || len(fractional) != 0 || exponential != 1000000000000 || !ok {
+		t.Error(signbit, string(integer), string(fractional), exponential, ok)
+	}
+
+	x, _ := ParseNumber("1e999999999999")
+	if y := x.Round(-1000000000000); y.Cmp(Number{}) != 0 {
+		t.Error(y.Parts())
+	}
+	if y := x.Round(-999999999999); y.Cmp(x) != 0 {
+		t.Error(y.Parts())
+	}
+}
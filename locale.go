@@ -0,0 +1,173 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Locale configures the separators used when parsing numbers, see Parser, and formatting them, see Locale.Join.
+type Locale struct {
+	// Group contains every rune that is accepted as a grouping (thousands) separator, e.g. "," for 1,234,567, which
+	// will be stripped wherever they appear, unless Grouping is set. The first rune is used for formatting.
+	Group string
+
+	// Decimal is the decimal separator, defaults to '.' if 0.
+	Decimal rune
+
+	// Space reports if a rune is insignificant, and should be stripped wherever it appears, defaults to
+	// unicode.IsSpace if nil. Note that runes in Group, or the Decimal rune, are never considered to be space.
+	Space func(r rune) bool
+
+	// Grouping optionally enables validation of the position of grouping separators, which must only appear
+	// between the digits of the integer component, where each value is the size of a group, starting from the
+	// decimal separator, and the last value repeats, e.g. []int{3} for 1,234,567, or []int{3, 2} for 12,34,567.
+	// Formatting will only output grouping separators if this is set.
+	Grouping []int
+}
+
+// Join is like the Join function, but formats using the locale, inserting grouping separators (if Grouping and
+// Group are set) and using the decimal separator, such that the output can be parsed by a Parser using the same
+// locale. A nil locale is treated as LocaleDefault.
+func (l *Locale) Join(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (string, bool) {
+	l = l.orDefault()
+
+	s, ok := Join(signbit, integer, fractional, exponential, ok)
+	if !ok {
+		return "", false
+	}
+
+	if signbit = strings.HasPrefix(s, "-"); signbit {
+		s = s[1:]
+	}
+	digits, rest := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		digits, rest = s[:i], s[i+1:]
+	}
+
+	var b strings.Builder
+	b.Grow(len(s) * 2)
+	if signbit {
+		b.WriteByte('-')
+	}
+
+	group, _ := utf8.DecodeRuneInString(l.Group)
+	if len(l.Grouping) == 0 || l.Group == "" {
+		b.WriteString(digits)
+	} else {
+		// split the integer digits into groups, from the right
+		var groups []string
+		for n := 0; len(digits) != 0; n++ {
+			size := l.groupSize(n)
+			if size <= 0 || size > len(digits) {
+				size = len(digits)
+			}
+			groups = append(groups, digits[len(digits)-size:])
+			digits = digits[:len(digits)-size]
+		}
+		for i := len(groups) - 1; i >= 0; i-- {
+			b.WriteString(groups[i])
+			if i != 0 {
+				b.WriteRune(group)
+			}
+		}
+	}
+
+	if rest != "" {
+		b.WriteRune(l.decimal())
+		b.WriteString(rest)
+	}
+
+	return b.String(), true
+}
+
+// Format returns the number formatted using Locale.Join, or one of +Inf, -Inf, NaN, or -0, see Class. A nil locale
+// is treated as LocaleDefault.
+func (x Number) Format(l *Locale) string {
+	if s, ok := x.specialString(); ok {
+		return s
+	}
+	s, _ := l.Join(x.Runes())
+	return s
+}
+
+// localeDefault is the Locale used if none is provided, which is separate from LocaleDefault, so that modifying the
+// exported variable does not change the behavior of ParseString, and the other package level functions.
+var localeDefault = Locale{Group: ",", Decimal: '.'}
+
+// The preset locales are provided for convenience, and must not be modified (including the Grouping slices, which
+// are shared by any copies), instead, copy the Locale, and assign a new Grouping slice, if required.
+var (
+	// LocaleDefault is the behavior of ParseString, stripping commas wherever they appear.
+	LocaleDefault = localeDefault
+
+	// LocaleEN is for English style numbers, e.g. 1,234,567.89, with validated grouping.
+	LocaleEN = Locale{Group: ",", Decimal: '.', Grouping: []int{3}}
+
+	// LocaleDE is for (continental) European style numbers, e.g. 1.234.567,89, with validated grouping.
+	LocaleDE = Locale{Group: ".", Decimal: ',', Grouping: []int{3}}
+
+	// LocaleFR is for French (and SI) style numbers, e.g. 1 234 567,89, accepting narrow no-break spaces, no-break
+	// spaces, thin spaces and spaces as grouping separators, with validated grouping.
+	LocaleFR = Locale{Group: "\u202f\u00a0\u2009 ", Decimal: ',', Grouping: []int{3}}
+
+	// LocaleCH is for Swiss style numbers, e.g. 1'234'567.89, accepting apostrophes and right single quotation
+	// marks as grouping separators, with validated grouping.
+	LocaleCH = Locale{Group: "'\u2019", Decimal: '.', Grouping: []int{3}}
+
+	// LocaleIN is for Indian style numbers, e.g. 12,34,567.89 (lakh and crore grouping), with validated grouping.
+	LocaleIN = Locale{Group: ",", Decimal: '.', Grouping: []int{3, 2}}
+)
+
+// orDefault returns l, or localeDefault (equivalent to LocaleDefault) if l is nil.
+func (l *Locale) orDefault() *Locale {
+	if l == nil {
+		return &localeDefault
+	}
+	return l
+}
+
+// decimal returns the decimal separator.
+func (l *Locale) decimal() rune {
+	if l.Decimal == 0 {
+		return '.'
+	}
+	return l.Decimal
+}
+
+// group returns true if r is a grouping separator.
+func (l *Locale) group(r rune) bool {
+	return strings.ContainsRune(l.Group, r)
+}
+
+// space returns true if r should be stripped.
+func (l *Locale) space(r rune) bool {
+	if l.Space == nil {
+		return unicode.IsSpace(r)
+	}
+	return l.Space(r)
+}
+
+// groupSize returns the expected size of the i-th group, counting from the decimal separator.
+func (l *Locale) groupSize(i int) int {
+	if i >= len(l.Grouping) {
+		i = len(l.Grouping) - 1
+	}
+	return l.Grouping[i]
+}
@@ -0,0 +1,183 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleLocale() {
+	fmt.Println(Parser{Locale: &LocaleEN}.ParseString("1,234,567.89"))
+	fmt.Println(Parser{Locale: &LocaleDE}.ParseString("1.234.567,89"))
+	fmt.Println(Parser{Locale: &LocaleFR}.ParseString(" 1 234 567,89 "))
+	fmt.Println(Parser{Locale: &LocaleCH}.ParseString("1'234'567.89"))
+	fmt.Println(Parser{Locale: &LocaleIN}.ParseString("12,34,567.89"))
+
+	// the default (as used by ParseString) strips commas wherever they appear
+	fmt.Println(ParseStringErr("1,5"))
+	fmt.Println(Parser{Locale: &LocaleDE}.ParseString("1,5"))
+
+	// Output:
+	// false 1234567 89 0 <nil>
+	// false 1234567 89 0 <nil>
+	// false 1234567 89 0 <nil>
+	// false 1234567 89 0 <nil>
+	// false 1234567 89 0 <nil>
+	// false 15  0 <nil>
+	// false 1 5 0 <nil>
+}
+
+func ExampleLocale_grouping() {
+	for _, s := range []string{
+		"1,234",
+		"12,34",
+		"1234,567",
+		",123",
+		"123,",
+		"1,,234",
+		"1.234,5",
+		"1,234.567,8",
+	} {
+		_, _, _, _, err := Parser{Locale: &LocaleEN}.ParseString(s)
+		fmt.Println(s, err, errors.Is(err, ErrGrouping))
+	}
+
+	// Output:
+	// 1,234 <nil> false
+	// 12,34 round: parsing "12,34": invalid grouping at offset 2 true
+	// 1234,567 round: parsing "1234,567": invalid grouping at offset 0 true
+	// ,123 round: parsing ",123": invalid grouping at offset 0 true
+	// 123, round: parsing "123,": invalid grouping at offset 3 true
+	// 1,,234 round: parsing "1,,234": invalid grouping at offset 1 true
+	// 1.234,5 round: parsing "1.234,5": invalid grouping at offset 5 true
+	// 1,234.567,8 round: parsing "1,234.567,8": invalid grouping at offset 9 true
+}
+
+func TestLocale_indian(t *testing.T) {
+	p := Parser{Locale: &LocaleIN}
+	for _, s := range []string{"1", "123", "1,234", "12,345", "1,23,456", "12,34,56,789", "-1,00,00,000.5e3"} {
+		if _, _, _, _, err := p.ParseString(s); err != nil {
+			t.Error(s, err)
+		}
+	}
+	for _, s := range []string{"1234,567", "1,234,567", "123,45,678", "1,23,4567"} {
+		if _, _, _, _, err := p.ParseString(s); !errors.Is(err, ErrGrouping) {
+			t.Error(s, err)
+		}
+	}
+}
+
+func TestLocale_space(t *testing.T) {
+	l := Locale{
+		Group:   "_",
+		Decimal: '٫',
+		Space: func(r rune) bool {
+			return r == '~'
+		},
+	}
+	x, err := Parser{Locale: &l}.ParseNumber("~1_2_34٫5_6~")
+	if err != nil || x.String() != "1234.56" {
+		t.Error(x, err)
+	}
+	if _, err := (Parser{Locale: &l}).ParseNumber("1 2"); !errors.Is(err, ErrSyntax) {
+		t.Error(err)
+	}
+	if _, err := (Parser{Locale: &l}).ParseNumber("1.2"); !errors.Is(err, ErrSyntax) {
+		t.Error(err)
+	}
+}
+
+func ExampleLocale_Join() {
+	for _, l := range []*Locale{&LocaleDefault, &LocaleEN, &LocaleDE, &LocaleCH, &LocaleIN} {
+		fmt.Println(l.Join(Runes(ParseString("-1234567.89"))))
+	}
+
+	// combined with rounding
+	fmt.Println(LocaleEN.Join(Apply(Runes(Parse(1234.5678)))(2)))
+
+	// the first group rune is used for formatting, e.g. a narrow no-break space for LocaleFR
+	x, _ := ParseNumber("1234567.89")
+	fmt.Printf("%q\n", x.Format(&LocaleFR))
+
+	// Output:
+	// -1234567.89 true
+	// -1,234,567.89 true
+	// -1.234.567,89 true
+	// -1'234'567.89 true
+	// -12,34,567.89 true
+	// 1,234.57 true
+	// "1\u202f234\u202f567,89"
+}
+
+func TestLocale_Join_roundTrip(t *testing.T) {
+	for _, l := range []*Locale{&LocaleDefault, &LocaleEN, &LocaleDE, &LocaleFR, &LocaleCH, &LocaleIN} {
+		for _, s := range []string{"0", "-0.5", "1", "12", "123", "1234", "12345", "123456", "1234567.125", "-98765432109876543210e-3", "1e30"} {
+			x, _ := ParseNumber(s)
+			formatted := x.Format(l)
+			y, err := Parser{Locale: l}.ParseNumber(formatted)
+			if err != nil {
+				t.Fatal(s, formatted, err)
+			}
+			if x.Cmp(y) != 0 {
+				t.Error(s, formatted, y)
+			}
+		}
+	}
+	if s, ok := LocaleEN.Join(Runes(ParseString("invalid"))); s != "" || ok {
+		t.Error(s, ok)
+	}
+	if s, ok := (&Locale{Group: ",", Grouping: []int{0}}).Join(Runes(ParseString("1234"))); s != "1234" || !ok {
+		t.Error(s, ok)
+	}
+	// the decimal separator defaults to '.'
+	if s, ok := (&Locale{}).Join(Runes(ParseString("1234.5"))); s != "1234.5" || !ok {
+		t.Error(s, ok)
+	}
+}
+
+func TestLocale_nil(t *testing.T) {
+	var l *Locale
+	if s, ok := l.Join(Runes(ParseString("-1234567.5"))); s != "-1234567.5" || !ok {
+		t.Error(s, ok)
+	}
+	if s, ok := l.Join(Runes(ParseString("invalid"))); s != "" || ok {
+		t.Error(s, ok)
+	}
+	x, _ := ParseNumber("1,234.5e3")
+	if s := x.Format(nil); s != "1234500" {
+		t.Error(s)
+	}
+	if s := Inf(-1).Format(nil); s != "-Inf" {
+		t.Error(s)
+	}
+}
+
+func TestLocaleDefault_modified(t *testing.T) {
+	defer func(l Locale) { LocaleDefault = l }(LocaleDefault)
+	LocaleDefault.Group, LocaleDefault.Decimal = ".", ','
+	if s, ok := DecimalString("1,000.5", 1); s != "1000.5" || !ok {
+		t.Error(s, ok)
+	}
+	if s, ok := (*Locale)(nil).Join(Runes(ParseString("1000.5"))); s != "1000.5" || !ok {
+		t.Error(s, ok)
+	}
+	if s, ok := LocaleDefault.Join(Runes(ParseString("1000.5"))); s != "1000,5" || !ok {
+		t.Error(s, ok)
+	}
+}
@@ -0,0 +1,280 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RoundingMode determines how discarded digits are handled when rounding, see ApplyMode.
+//
+// NOTES:
+// - the "half" modes round to the nearest value, and only differ in how they handle ties (exactly half way)
+// - up and down refer to the magnitude (away from or toward zero), unless they are paired with half, in which case
+//   they refer to positive and negative infinity, as is conventional
+type RoundingMode int
+
+const (
+	// RoundHalfAwayFromZero rounds to nearest, with ties away from zero, this is the behavior of Apply.
+	RoundHalfAwayFromZero RoundingMode = iota
+
+	// RoundHalfTowardZero rounds to nearest, with ties toward zero.
+	RoundHalfTowardZero
+
+	// RoundHalfEven rounds to nearest, with ties to the nearest even digit (banker's rounding).
+	RoundHalfEven
+
+	// RoundHalfUp rounds to nearest, with ties toward positive infinity.
+	RoundHalfUp
+
+	// RoundHalfDown rounds to nearest, with ties toward negative infinity.
+	RoundHalfDown
+
+	// RoundCeiling rounds toward positive infinity.
+	RoundCeiling
+
+	// RoundFloor rounds toward negative infinity.
+	RoundFloor
+
+	// RoundUp rounds away from zero.
+	RoundUp
+
+	// RoundDown rounds toward zero (truncation).
+	RoundDown
+
+	// Round05Up rounds toward zero, unless the last retained digit would be 0 or 5, in which case it rounds away
+	// from zero, which preserves information for later re-rounding (to fewer digits) in the same way as IEEE 754.
+	Round05Up
+)
+
+// String returns the name of the rounding mode.
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundHalfAwayFromZero:
+		return "RoundHalfAwayFromZero"
+	case RoundHalfTowardZero:
+		return "RoundHalfTowardZero"
+	case RoundHalfEven:
+		return "RoundHalfEven"
+	case RoundHalfUp:
+		return "RoundHalfUp"
+	case RoundHalfDown:
+		return "RoundHalfDown"
+	case RoundCeiling:
+		return "RoundCeiling"
+	case RoundFloor:
+		return "RoundFloor"
+	case RoundUp:
+		return "RoundUp"
+	case RoundDown:
+		return "RoundDown"
+	case Round05Up:
+		return "Round05Up"
+	default:
+		return fmt.Sprintf("RoundingMode(%d)", int(m))
+	}
+}
+
+// valid returns true if the rounding mode is one of the defined constants.
+func (m RoundingMode) valid() bool {
+	return m >= RoundHalfAwayFromZero && m <= Round05Up
+}
+
+// ApplyMode is like Apply but supports rounding modes other than RoundHalfAwayFromZero, note it will return all
+// zero values if ok was false or the mode is not valid.
+func ApplyMode(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) func(n int, mode RoundingMode) (signbit bool, integer []rune, fractional []rune, exponential int, ok bool) {
+	return func(n int, mode RoundingMode) (bool, []rune, []rune, int, bool) {
+		if !ok || !mode.valid() {
+			return false, nil, nil, 0, false
+		}
+		integer, exponential := applyFunc(integer, fractional, exponential, n, roundModeFunc(mode, signbit))
+		return signbit, integer, nil, exponential, true
+	}
+}
+
+// roundModeFunc returns a round func for applyFunc, which uses roundMode.
+func roundModeFunc(mode RoundingMode, signbit bool) func(integer []rune, fractional []rune, zeros int) bool {
+	return func(integer []rune, fractional []rune, zeros int) bool {
+		if zeros != 0 {
+			// all digits will be discarded, and the first discarded digit would be a padded zero, so we only need
+			// to keep track of if any of the discarded digits were non-zero
+			discard := []rune{'0'}
+			if strings.Trim(string(fractional), "0") != "" {
+				discard = append(discard, '1')
+			}
+			fractional = discard
+		}
+		return roundMode(mode, signbit, integer, fractional)
+	}
+}
+
+// applyFunc implements the Apply variants, shifting the digits such that integer contains the digits to keep, when
+// rounding to n decimal places, and calling round with the discarded digits, to decide if we need to add 1 to the
+// uint that integer represents, returning the new integer and exponential (the fractional is always discarded).
+//
+// The discarded digits will be preceded by the given number of (implied) zeros, which is only non-zero if all the
+// digits are discarded, avoiding padding, so the time and memory used is O(digits), rather than O(n).
+func applyFunc(integer []rune, fractional []rune, exponential int, n int, round func(integer []rune, fractional []rune, zeros int) bool) ([]rune, int) {
+	// adjust the n decimal arg by the exponential, so we round to the actual point we want
+	// e.g. if we want to round to two decimal places, and have (false, "12", "1456", 1, true), then since the
+	// actual number is 121.456 (=12.1456 x 10 ^ 1), we want to use 3 digits from fractional, instead of 2
+	// NOTE: this saturates, which only affects the (unbounded) number of zeros preceding the discarded digits
+	m := addSaturating(n, exponential)
+
+	// shift m digits between fractional and integer
+	// NOTE: we also adjust the exponential to keep track of the actual number, which is -n, unless there are too
+	// few digits in fractional
+	zeros := 0
+	switch {
+	case m > len(fractional):
+		// shifting past the end of fractional would just pad integer with zeros, leaving nothing to round, so
+		// we avoid that, only shifting the digits we have
+		m = len(fractional)
+		fallthrough
+	case m > 0:
+		exponential -= m
+		integer, fractional = shiftLeft(integer, fractional, m)
+	case m < -len(integer):
+		// all digits will be discarded, preceded by zeros
+		exponential = -n
+		zeros = subSaturating(subSaturating(0, m), len(integer))
+		digits := make([]rune, 0, len(integer)+len(fractional))
+		digits = append(digits, integer...)
+		digits = append(digits, fractional...)
+		integer, fractional = nil, digits
+	case m < 0:
+		exponential = -n
+		integer, fractional = shiftRight(integer, fractional, -m)
+	}
+
+	// decide if we need to add 1 to the uint that integer represents (round part 1)
+	// NOTE: integer may share a backing array with the caller's slices, and the closures returned by the Apply
+	// variants may be called any number of times, so we increment a copy
+	if round(integer, fractional, zeros) {
+		integer = incrementInteger(append([]rune(nil), integer...))
+	}
+
+	if n == minInt {
+		// -n would overflow, so scale by 10 instead
+		if len(integer) == 0 {
+			return nil, 0
+		}
+		return append(append([]rune(nil), integer...), '0'), maxInt
+	}
+
+	// anything left in fractional is discarded by the caller (round part 2)
+	return integer, exponential
+}
+
+// DecimalMode is like Decimal but supports rounding modes other than RoundHalfAwayFromZero.
+func DecimalMode(v interface{}, n int, mode RoundingMode) (string, bool) {
+	return decimalMode(v, String, n, mode)
+}
+
+// DecimalStringMode is the DecimalMode implementation after converting the value to a string using String.
+func DecimalStringMode(s string, n int, mode RoundingMode) (string, bool) {
+	return Join(ApplyMode(Runes(ParseString(s)))(n, mode))
+}
+
+// decimalMode implements the Decimal functions that accept a value, using DecimalStringMode after converting the
+// value to a string using format (String, StringShortest, or StringExact), except for the infinities and NaN, as
+// classified by nonFinite, which are returned as formatted by String, rather than failing to parse them.
+func decimalMode(v interface{}, format func(v interface{}) string, n int, mode RoundingMode) (string, bool) {
+	if x, ok := nonFinite(v); ok {
+		if !mode.valid() {
+			return "", false
+		}
+		return x.String(), true
+	}
+	return DecimalStringMode(format(v), n, mode)
+}
+
+const (
+	discardedZero = iota
+	discardedBelowHalf
+	discardedHalf
+	discardedAboveHalf
+)
+
+// discarded classifies the fractional component (all digits that will be discarded) relative to one half, looking
+// past the first digit in order to detect exact ties, and exact zeros.
+func discarded(fractional []rune) int {
+	if len(fractional) == 0 {
+		return discardedZero
+	}
+	rest := discardedZero
+	for _, r := range fractional[1:] {
+		if r != '0' {
+			rest = discardedBelowHalf
+			break
+		}
+	}
+	switch {
+	case fractional[0] > '5':
+		return discardedAboveHalf
+	case fractional[0] == '5' && rest != discardedZero:
+		return discardedAboveHalf
+	case fractional[0] == '5':
+		return discardedHalf
+	case fractional[0] == '0' && rest == discardedZero:
+		return discardedZero
+	default:
+		return discardedBelowHalf
+	}
+}
+
+// roundMode returns true if the uint that integer represents needs to be incremented (increasing the magnitude), in
+// order to round away the fractional component, using the given mode, which must be valid.
+func roundMode(mode RoundingMode, signbit bool, integer []rune, fractional []rune) bool {
+	if mode == RoundHalfAwayFromZero {
+		return roundFractional(fractional)
+	}
+
+	d := discarded(fractional)
+	if d == discardedZero {
+		// nothing to round, all modes agree
+		return false
+	}
+
+	// the last retained digit, used by half even and 05 up
+	last := '0'
+	if l := len(integer); l != 0 {
+		last = integer[l-1]
+	}
+
+	switch mode {
+	case RoundHalfTowardZero:
+		return d == discardedAboveHalf
+	case RoundHalfEven:
+		return d == discardedAboveHalf || (d == discardedHalf && (last-'0')%2 == 1)
+	case RoundHalfUp:
+		return d == discardedAboveHalf || (d == discardedHalf && !signbit)
+	case RoundHalfDown:
+		return d == discardedAboveHalf || (d == discardedHalf && signbit)
+	case RoundCeiling:
+		return !signbit
+	case RoundFloor:
+		return signbit
+	case RoundUp:
+		return true
+	case RoundDown:
+		return false
+	default: // Round05Up
+		return last == '0' || last == '5'
+	}
+}
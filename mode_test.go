@@ -0,0 +1,220 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleDecimalMode() {
+	for _, mode := range []RoundingMode{
+		RoundHalfAwayFromZero,
+		RoundHalfTowardZero,
+		RoundHalfEven,
+		RoundHalfUp,
+		RoundHalfDown,
+		RoundCeiling,
+		RoundFloor,
+		RoundUp,
+		RoundDown,
+		Round05Up,
+	} {
+		fmt.Printf("%-21s", mode)
+		for _, v := range []string{"2.5", "-2.5", "3.5", "2.51", "-2.49", "0.01"} {
+			s, _ := DecimalStringMode(v, 0, mode)
+			fmt.Printf(" %3s", s)
+		}
+		fmt.Println()
+	}
+
+	// Output:
+	// RoundHalfAwayFromZero   3  -3   4   3  -2   0
+	// RoundHalfTowardZero     2  -2   3   3  -2   0
+	// RoundHalfEven           2  -2   4   3  -2   0
+	// RoundHalfUp             3  -2   4   3  -2   0
+	// RoundHalfDown           2  -3   3   3  -2   0
+	// RoundCeiling            3  -2   4   3  -2   1
+	// RoundFloor              2  -3   3   2  -3   0
+	// RoundUp                 3  -3   4   3  -3   1
+	// RoundDown               2  -2   3   2  -2   0
+	// Round05Up               2  -2   3   2  -2   1
+}
+
+func ExampleDecimalMode_bankers() {
+	// half even only rounds to even when the discarded digits are exactly half
+	fmt.Println(DecimalMode("0.125", 2, RoundHalfEven))
+	fmt.Println(DecimalMode("0.135", 2, RoundHalfEven))
+	fmt.Println(DecimalMode("0.1250000000001", 2, RoundHalfEven))
+
+	// negative n, and the exponential, work the same as Decimal
+	fmt.Println(DecimalMode("2.5e3", -3, RoundHalfEven))
+	fmt.Println(DecimalMode("3.5e3", -3, RoundHalfEven))
+
+	// invalid input, or an invalid mode, returns false
+	fmt.Println(DecimalMode("abc", 2, RoundHalfEven))
+	fmt.Println(DecimalMode("1.5", 2, RoundingMode(-1)))
+
+	// Output:
+	// 0.12 true
+	// 0.14 true
+	// 0.13 true
+	// 2000 true
+	// 4000 true
+	//  false
+	//  false
+}
+
+func TestRoundingMode_String(t *testing.T) {
+	if s := RoundingMode(100).String(); s != "RoundingMode(100)" {
+		t.Error(s)
+	}
+	if s := Round05Up.String(); s != "Round05Up" {
+		t.Error(s)
+	}
+}
+
+func TestApply_matchesApplyMode(t *testing.T) {
+	for _, s := range []string{"", "0", "-0.5", "0.5", "-1.49999", "99.95", "1e-3", "-123.456e2", "x"} {
+		for n := -3; n <= 3; n++ {
+			a, aok := Join(Apply(Runes(ParseString(s)))(n))
+			b, bok := Join(ApplyMode(Runes(ParseString(s)))(n, RoundHalfAwayFromZero))
+			if a != b || aok != bok {
+				t.Error(s, n, a, aok, b, bok)
+			}
+		}
+	}
+}
+
+func TestApply_reuse(t *testing.T) {
+	f := Apply(Runes(ParseString("9.5")))
+	for i := 0; i < 3; i++ {
+		if s, ok := Join(f(0)); s != "10" || !ok {
+			t.Error(i, s, ok)
+		}
+	}
+	g := ApplyMode(Runes(ParseString("-0.995")))
+	for i := 0; i < 3; i++ {
+		if s, ok := Join(g(2, RoundHalfEven)); s != "-1" || !ok {
+			t.Error(i, s, ok)
+		}
+		if s, ok := Join(g(1, RoundDown)); s != "-0.9" || !ok {
+			t.Error(i, s, ok)
+		}
+	}
+	// Join appends padding zeros to the integer returned when rounding to tens and above
+	k := Apply(Runes(ParseString("1234.5")))
+	for i := 0; i < 3; i++ {
+		if s, ok := Join(k(-2)); s != "1200" || !ok {
+			t.Error(i, s, ok)
+		}
+		if s, ok := Join(k(1)); s != "1234.5" || !ok {
+			t.Error(i, s, ok)
+		}
+	}
+	h := ApplySignificant(Runes(ParseString("9.96")))
+	for i := 0; i < 3; i++ {
+		if s, ok := Join(h(2)); s != "10" || !ok {
+			t.Error(i, s, ok)
+		}
+	}
+}
+
+func TestApplyMode_nRange(t *testing.T) {
+	for _, tc := range []struct {
+		Input  string
+		N      int
+		Mode   RoundingMode
+		Output string
+	}{
+		{"1.5e5", maxInt, RoundHalfEven, "150000"},
+		{"1.25e-5", maxInt, RoundHalfEven, "0.0000125"},
+		{"1.5e5", minInt, RoundHalfEven, "0"},
+		{"-1.5e5", minInt, RoundFloor, "-10e9223372036854775807"},
+		{"1e-9223372036854775808", -5, RoundHalfEven, "0"},
+		{"1e-9223372036854775808", -5, RoundUp, "1e5"},
+		{"1e-9223372036854775808", maxInt, RoundUp, "1e-9223372036854775807"},
+		{"0.5e-9223372036854775807", maxInt, RoundHalfEven, "0"},
+		{"0.5e-9223372036854775807", maxInt, RoundHalfUp, "1e-9223372036854775807"},
+		{"12e9223372036854775807", minInt, RoundHalfEven, "10e9223372036854775807"},
+		{"15e9223372036854775807", minInt, RoundHalfEven, "20e9223372036854775807"},
+		{"12e9223372036854775807", -maxInt, RoundHalfEven, "12e9223372036854775807"},
+	} {
+		expected, _ := ParseNumber(tc.Output)
+		x, ok := NewNumberRunes(ApplyMode(Runes(ParseString(tc.Input)))(tc.N, tc.Mode))
+		if !ok || x.Cmp(expected) != 0 {
+			t.Error(tc.Input, tc.N, tc.Mode, ok, x.integer, x.fractional, x.exponential)
+		}
+		var condition Condition
+		y, ok := NewNumberRunes(ApplyCondition(Runes(ParseString(tc.Input)))(tc.N, tc.Mode, &condition))
+		if input, _ := ParseNumber(tc.Input); !ok || y.Cmp(x) != 0 || (condition.Discarded.IsZero() != (x.Cmp(input) == 0)) {
+			t.Error(tc.Input, tc.N, tc.Mode, ok, y.integer, y.fractional, y.exponential, condition.Direction)
+		}
+	}
+}
+
+func TestDiscarded(t *testing.T) {
+	type TestCase struct {
+		Input  string
+		Output int
+	}
+
+	testCases := []TestCase{
+		{
+			Input:  "",
+			Output: discardedZero,
+		},
+		{
+			Input:  "0000",
+			Output: discardedZero,
+		},
+		{
+			Input:  "0001",
+			Output: discardedBelowHalf,
+		},
+		{
+			Input:  "4999999",
+			Output: discardedBelowHalf,
+		},
+		{
+			Input:  "5",
+			Output: discardedHalf,
+		},
+		{
+			Input:  "50000",
+			Output: discardedHalf,
+		},
+		{
+			Input:  "500001",
+			Output: discardedAboveHalf,
+		},
+		{
+			Input:  "6",
+			Output: discardedAboveHalf,
+		},
+	}
+
+	for i, testCase := range testCases {
+		name := fmt.Sprintf("TestDiscarded_#%d", i+1)
+
+		output := discarded([]rune(testCase.Input))
+
+		if output != testCase.Output {
+			t.Error(name, "output", output, "!= expected", testCase.Output)
+		}
+	}
+}
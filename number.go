@@ -0,0 +1,172 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"strings"
+)
+
+// Number is an immutable decimal value, integer.fractional x 10 ^ exponential, negative if signbit is set, which is
+// equivalent to the output of ParseString where ok was true. The zero value is zero.
+//
+// Numbers are comparable, and may be used as map keys, but note that the same value may be represented with
+// different exponentials, e.g. the result of ParseString("1e1") is not equal to ParseString("10").
+//
+// A number may also be one of the special values, infinity, NaN, or negative zero, see Class.
+//
+// Arithmetic is exact within the range of int exponents, where any digits below 10 ^ min int (of the operands, or
+// results) are discarded, rounding toward zero, and Mul overflows to an infinity if the exponent of the result
+// would exceed the max int, note that Cmp is always exact.
+type Number struct {
+	signbit     bool
+	integer     string
+	fractional  string
+	exponential int
+	special     special
+}
+
+// NewNumber builds a Number from the output of Parse or ParseString (or anything else with the same signature),
+// returning false if ok was false, or if integer or fractional contain anything other than ASCII digits.
+//
+// NOTE: leading zeros are stripped from integer, trailing zeros are stripped from fractional, and the signbit is
+// cleared for zero, in the same way as ParseString, and zero will always have a zero exponential.
+func NewNumber(signbit bool, integer string, fractional string, exponential int, ok bool) (Number, bool) {
+	if !ok || !isDigits(integer) || !isDigits(fractional) {
+		return Number{}, false
+	}
+
+	integer = strings.TrimLeft(integer, "0")
+	fractional = strings.TrimRight(fractional, "0")
+
+	if integer == "" && fractional == "" {
+		signbit = false
+		exponential = 0
+	}
+
+	return Number{
+		signbit:     signbit,
+		integer:     integer,
+		fractional:  fractional,
+		exponential: exponential,
+	}, true
+}
+
+// NewNumberRunes is NewNumber for the output of Runes, Apply or ApplyMode.
+func NewNumberRunes(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (Number, bool) {
+	return NewNumber(signbit, string(integer), string(fractional), exponential, ok)
+}
+
+// Signbit returns true if the number is negative, it is always false for zero, unless it is negative zero.
+func (x Number) Signbit() bool { return x.signbit }
+
+// Integer returns the integer digits, with leading zeros stripped.
+func (x Number) Integer() string { return x.integer }
+
+// Fractional returns the fractional digits, with trailing zeros stripped.
+func (x Number) Fractional() string { return x.fractional }
+
+// Exponential returns the (base 10) exponential.
+func (x Number) Exponential() int { return x.exponential }
+
+// IsZero returns true if the number evaluates to zero, including negative zero.
+func (x Number) IsZero() bool {
+	return x.special == specialNone && x.integer == "" && x.fractional == ""
+}
+
+// Parts returns the number in the same format as ParseString, for use with the other functions in this package,
+// note that ok will be true unless the number is an infinity or NaN, and negative zero is returned as zero.
+func (x Number) Parts() (signbit bool, integer string, fractional string, exponential int, ok bool) {
+	if !x.isFinite() {
+		return false, "", "", 0, false
+	}
+	return x.signbit && !x.IsZero(), x.integer, x.fractional, x.exponential, true
+}
+
+// Runes is equivalent to Runes(x.Parts()), for use with Apply, Join, etc.
+func (x Number) Runes() (signbit bool, integer []rune, fractional []rune, exponential int, ok bool) {
+	return Runes(x.Parts())
+}
+
+// Round returns the number rounded to n decimal places, see Apply.
+func (x Number) Round(n int) Number {
+	if x.isSpecial() {
+		return x
+	}
+	r, _ := NewNumberRunes(Apply(x.Runes())(n))
+	return r
+}
+
+// RoundMode returns the number rounded to n decimal places using the given mode, or false if the mode is not valid,
+// see ApplyMode.
+func (x Number) RoundMode(n int, mode RoundingMode) (Number, bool) {
+	if x.isSpecial() {
+		if !mode.valid() {
+			return Number{}, false
+		}
+		return x, true
+	}
+	return NewNumberRunes(ApplyMode(x.Runes())(n, mode))
+}
+
+// Normalize returns an equal number with a zero exponential, i.e. with the digits moved between integer and
+// fractional such that the result is in the same form as Join.
+func (x Number) Normalize() Number {
+	if x.exponential == 0 || x.isSpecial() {
+		return x
+	}
+	signbit, integer, fractional, exponential, ok := x.Runes()
+	integer, fractional = shift(integer, fractional, exponential)
+	r, _ := NewNumberRunes(signbit, integer, fractional, 0, ok)
+	return r
+}
+
+// String returns the number formatted using Join, or one of +Inf, -Inf, NaN, or -0, see Class.
+func (x Number) String() string {
+	if s, ok := x.specialString(); ok {
+		return s
+	}
+	s, _ := Join(x.Runes())
+	return s
+}
+
+// Float32 converts the number to a float32, see the Float32 function, note that the special values are converted
+// to their float32 equivalents, see Class.
+func (x Number) Float32() (float32, error) {
+	if f, ok := x.specialFloat64(); ok {
+		return float32(f), nil
+	}
+	return Float32(x.Runes())
+}
+
+// Float64 converts the number to a float64, see the Float64 function, note that the special values are converted
+// to their float64 equivalents, see Class.
+func (x Number) Float64() (float64, error) {
+	if f, ok := x.specialFloat64(); ok {
+		return f, nil
+	}
+	return Float64(x.Runes())
+}
+
+// isDigits returns true if s consists only of ASCII digits (including if it is empty).
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
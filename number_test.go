@@ -0,0 +1,144 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleNumber() {
+	x, ok := NewNumber(Parse("-0001,234.5678900 x 10 ^ -2"))
+	fmt.Println(ok)
+	fmt.Println(x.Signbit(), x.Integer(), x.Fractional(), x.Exponential())
+	fmt.Println(x)
+	fmt.Println(x.Round(2))
+	fmt.Println(x.RoundMode(1, RoundCeiling))
+	fmt.Println(x.Normalize().Parts())
+
+	// numbers can be used with the rest of the package via Parts or Runes
+	fmt.Println(Join(Apply(x.Runes())(0)))
+	fmt.Println(NewNumber(EnsureExponentFloat64(x.Parts())))
+
+	// Output:
+	// true
+	// true 1234 56789 -2
+	// -12.3456789
+	// -12.35
+	// -12.3 true
+	// true 12 3456789 0 true
+	// -12 true
+	// -12.3456789 true
+}
+
+func ExampleNumber_mapKey() {
+	totals := make(map[Number]int)
+	for _, s := range []string{"1.50", "001.5", "1.5e0", "-0", "0.000"} {
+		x, _ := NewNumber(ParseString(s))
+		totals[x]++
+	}
+	one, _ := NewNumber(ParseString("1.5"))
+	fmt.Println(totals[one], totals[Number{}])
+
+	// Output:
+	// 3 2
+}
+
+func TestNewNumber_invalid(t *testing.T) {
+	if x, ok := NewNumber(ParseString("abc")); ok || x != (Number{}) {
+		t.Error(x, ok)
+	}
+	if x, ok := NewNumber(false, "12a", "", 0, true); ok || x != (Number{}) {
+		t.Error(x, ok)
+	}
+	if x, ok := NewNumber(false, "12", "-1", 0, true); ok || x != (Number{}) {
+		t.Error(x, ok)
+	}
+	if x, ok := (Number{}).RoundMode(2, RoundingMode(-1)); ok || x != (Number{}) {
+		t.Error(x, ok)
+	}
+}
+
+func TestNewNumber_normalises(t *testing.T) {
+	x, ok := NewNumberRunes(true, []rune("000"), []rune("000"), 5, true)
+	if !ok || x != (Number{}) || !x.IsZero() || x.String() != "0" {
+		t.Error(x, ok)
+	}
+	x, ok = NewNumber(true, "0012", "3400", -1, true)
+	if !ok || x.IsZero() || x != (Number{signbit: true, integer: "12", fractional: "34", exponential: -1}) {
+		t.Error(x, ok)
+	}
+}
+
+func TestNumber_Normalize(t *testing.T) {
+	type TestCase struct {
+		Input  string
+		Output Number
+	}
+
+	testCases := []TestCase{
+		{
+			Input:  "0",
+			Output: Number{},
+		},
+		{
+			Input:  "0e10",
+			Output: Number{},
+		},
+		{
+			Input:  "-1.25e1",
+			Output: Number{signbit: true, integer: "12", fractional: "5"},
+		},
+		{
+			Input:  "1.25e5",
+			Output: Number{integer: "125000"},
+		},
+		{
+			Input:  "12.5e-4",
+			Output: Number{fractional: "00125"},
+		},
+	}
+
+	for i, testCase := range testCases {
+		name := fmt.Sprintf("TestNumber_Normalize_#%d", i+1)
+
+		x, ok := NewNumber(ParseString(testCase.Input))
+		if !ok {
+			t.Fatal(name, "failed to parse")
+		}
+
+		output := x.Normalize()
+
+		if output != testCase.Output {
+			t.Error(name, "output", output, "!= expected", testCase.Output)
+		}
+
+		if output.String() != x.String() {
+			t.Error(name, "string", output.String(), "!= input", x.String())
+		}
+	}
+}
+
+func TestNumber_floats(t *testing.T) {
+	x, _ := NewNumber(Parse(0.1))
+	if f, err := x.Float64(); err != nil || f != 0.1 {
+		t.Error(f, err)
+	}
+	if f, err := x.Float32(); err != nil || f != 0.1 {
+		t.Error(f, err)
+	}
+}
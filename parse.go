@@ -0,0 +1,342 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Parser implements configurable parsing, where the zero value parses in the same way as ParseString.
+type Parser struct {
+	// Locale configures the separators, defaults to (an unmodified) LocaleDefault if nil.
+	Locale *Locale
+
+	// Limits restricts the size of the numbers that may be parsed, using MaxExponent and MaxDigits.
+	Limits Limits
+
+	// Lenient enables parsing of numbers with a decimal point, but no digits on one side of it, e.g. ".5", "-.25",
+	// or "5.", as accepted by strconv.ParseFloat, which will be parsed the same as "0.5", "-0.25" and "5".
+	Lenient bool
+
+	// Specials enables parsing of the special values, see Class, which is only supported by ParseNumber, and accepts
+	// (case insensitive) inf, infinity, ∞ and nan, with an optional sign, as well as negative zero, e.g. "-0.0".
+	// This allows the output of String(float64) to be parsed without loss, including for math.Inf and math.NaN.
+	Specials bool
+
+	// Units are the suffixes that may follow the number, such as PercentUnits, where the longest matching suffix
+	// (if any) is stripped, and its Exponent added to the exponential, e.g. "12.5%" parses as 12.5 x 10 ^ -2.
+	Units []Unit
+}
+
+// Parse is like the Parse function, but returns a *ParseError describing the problem, instead of ok=false, and will
+// return all zero values (except err) on failure.
+func (p Parser) Parse(v interface{}) (signbit bool, integer string, fractional string, exponential int, err error) {
+	return p.ParseString(String(v))
+}
+
+// ParseString is the implementation of Parse after string conversion has been applied.
+func (p Parser) ParseString(s string) (signbit bool, integer string, fractional string, exponential int, err error) {
+	var sc scanner
+	sc.init(&p, s)
+	return sc.scan()
+}
+
+// ParseNumber parses a string in the same way as ParseString, returning a Number.
+func (p Parser) ParseNumber(s string) (Number, error) {
+	var sc scanner
+	sc.init(&p, s)
+	if p.Specials {
+		if x, ok := sc.special(); ok {
+			return x, nil
+		}
+	}
+	signbit, integer, fractional, exponential, err := sc.scan()
+	if err != nil {
+		return Number{}, err
+	}
+	x, _ := NewNumber(signbit, integer, fractional, exponential, true)
+	if p.Specials && x.IsZero() && sc.text[0] == '-' {
+		x.signbit = true
+	}
+	return x, nil
+}
+
+// locale returns the Locale, defaulting to localeDefault.
+func (p *Parser) locale() *Locale {
+	return p.Locale.orDefault()
+}
+
+// ParseErr is like Parse, but returns a *ParseError describing the problem, instead of ok=false.
+func ParseErr(v interface{}) (signbit bool, integer string, fractional string, exponential int, err error) {
+	return Parser{}.Parse(v)
+}
+
+// ParseStringErr is like ParseString, but returns a *ParseError describing the problem, instead of ok=false, and
+// will return all zero values (except err) on failure.
+func ParseStringErr(s string) (signbit bool, integer string, fractional string, exponential int, err error) {
+	return Parser{}.ParseString(s)
+}
+
+// ParseNumber parses a string in the same way as ParseString, returning a Number, or a *ParseError.
+func ParseNumber(s string) (Number, error) {
+	return Parser{}.ParseNumber(s)
+}
+
+// scanner implements Parser.ParseString, operating on the input after stripping whitespace and grouping
+// separators, and translating the decimal separator, while tracking the offsets within the original input, for
+// error reporting.
+type scanner struct {
+	parser *Parser
+	locale *Locale
+	input  string
+	// text is input with all whitespace and grouping separators stripped, and the decimal separator as '.'
+	text string
+	// offsets maps each byte of text to the byte offset in input, with an extra element for the end of input
+	offsets []int
+	// groups contains the position in text of each grouping separator, if the locale validates grouping
+	groups []scannerGroup
+	// pos is the current position in text
+	pos int
+	// count is the number of digits scanned so far
+	count int
+}
+
+type scannerGroup struct {
+	// pos is the position in text of the next byte after the separator
+	pos int
+	// offset is the byte offset of the separator in input
+	offset int
+	// space is true if the separator would otherwise be stripped as space
+	space bool
+}
+
+func (sc *scanner) init(p *Parser, s string) {
+	var (
+		b       strings.Builder
+		offsets = make([]int, 0, len(s)+1)
+		groups  []scannerGroup
+		locale  = p.locale()
+	)
+	decimal := locale.decimal()
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == decimal:
+			b.WriteByte('.')
+			offsets = append(offsets, i)
+		case locale.group(r):
+			if len(locale.Grouping) != 0 {
+				groups = append(groups, scannerGroup{pos: b.Len(), offset: i, space: locale.space(r)})
+			}
+		case locale.space(r):
+		case r == '.':
+			// a '.' that isn't the decimal separator is invalid, so we write a byte that will fail to parse
+			b.WriteByte(0)
+			offsets = append(offsets, i)
+		default:
+			b.WriteString(s[i : i+size])
+			for j := 0; j < size; j++ {
+				offsets = append(offsets, i+j)
+			}
+		}
+		i += size
+	}
+	offsets = append(offsets, len(s))
+	*sc = scanner{
+		parser:  p,
+		locale:  locale,
+		input:   s,
+		text:    b.String(),
+		offsets: offsets,
+		groups:  groups,
+	}
+}
+
+// grouping validates the positions of any grouping separators, given the start and end positions of the integer
+// component, in text.
+func (sc *scanner) grouping(start, end int) error {
+	var (
+		pos  = end
+		size int
+		n    int
+	)
+	for i := len(sc.groups) - 1; i >= 0; i-- {
+		g := sc.groups[i]
+		if g.pos <= start || g.pos >= end {
+			if g.space {
+				// it's not between digits, so we treat it as space
+				continue
+			}
+			return sc.groupingError(g.offset)
+		}
+		size = sc.locale.groupSize(n)
+		n++
+		if pos-g.pos != size {
+			return sc.groupingError(g.offset)
+		}
+		pos = g.pos
+	}
+	if n != 0 && pos-start > sc.locale.groupSize(n) {
+		return sc.groupingError(sc.offsets[start])
+	}
+	return nil
+}
+
+func (sc *scanner) groupingError(offset int) *ParseError {
+	return &ParseError{
+		Input:  sc.input,
+		Offset: offset,
+		Kind:   ParseErrorGrouping,
+	}
+}
+
+// error builds a *ParseError at the given position in text.
+func (sc *scanner) error(pos int, kind ParseErrorKind, err error) *ParseError {
+	return &ParseError{
+		Input:  sc.input,
+		Offset: sc.offsets[pos],
+		Kind:   kind,
+		Err:    err,
+	}
+}
+
+// peek returns the byte at the current position, or 0 at the end of the text.
+func (sc *scanner) peek() byte {
+	if sc.pos < len(sc.text) {
+		return sc.text[sc.pos]
+	}
+	return 0
+}
+
+// sign consumes an optional sign, returning true if it was negative.
+func (sc *scanner) sign() bool {
+	switch sc.peek() {
+	case '-':
+		sc.pos++
+		return true
+	case '+':
+		sc.pos++
+	}
+	return false
+}
+
+// digits consumes one or more ASCII digits, returning them, or a syntax error if there were none, counting them
+// toward Limits.MaxDigits if count is true.
+func (sc *scanner) digits(count bool) (string, error) {
+	start := sc.pos
+	for c := sc.peek(); c >= '0' && c <= '9'; c = sc.peek() {
+		sc.pos++
+	}
+	if sc.pos == start {
+		return "", sc.error(sc.pos, ParseErrorSyntax, nil)
+	}
+	if !count {
+		return sc.text[start:sc.pos], nil
+	}
+	if max := sc.parser.Limits.MaxDigits; max > 0 && sc.count+sc.pos-start > max {
+		return "", sc.error(start+max-sc.count, ParseErrorLimit, nil)
+	}
+	sc.count += sc.pos - start
+	return sc.text[start:sc.pos], nil
+}
+
+// marker consumes an exponent marker (x10^, *10^, or e, case insensitive), returning false if there was none.
+func (sc *scanner) marker() bool {
+	rest := sc.text[sc.pos:]
+	for _, m := range [...]string{`e`, `x10^`, `*10^`} {
+		if len(rest) >= len(m) && strings.EqualFold(rest[:len(m)], m) {
+			sc.pos += len(m)
+			return true
+		}
+	}
+	return false
+}
+
+func (sc *scanner) scan() (signbit bool, integer string, fractional string, exponential int, err error) {
+	if len(sc.text) == 0 {
+		return false, "", "", 0, sc.error(0, ParseErrorEmpty, nil)
+	}
+
+	unit := sc.unit()
+
+	signbit = sc.sign()
+
+	// integer component, which is required (unless lenient, and followed by a fractional component), trim all
+	// leading zeros
+	start := sc.pos
+	if integer, err = sc.digits(true); err != nil && !(sc.parser.Lenient && sc.peek() == '.') {
+		return false, "", "", 0, err
+	}
+	if err = sc.grouping(start, sc.pos); err != nil {
+		return false, "", "", 0, err
+	}
+	integer = strings.TrimLeft(integer, "0")
+
+	// optional fractional component, trim all trailing zeros
+	if sc.peek() == '.' {
+		sc.pos++
+		// the digits are required, unless lenient, and we had integer digits
+		if fractional, err = sc.digits(true); err != nil && !(sc.parser.Lenient && sc.pos-1 > start) {
+			return false, "", "", 0, err
+		}
+		fractional = strings.TrimRight(fractional, "0")
+	}
+
+	if signbit && integer == "" && fractional == "" {
+		// we parsed a negative sign, but we then parsed an expression that evaluates to 0, remove the negative
+		signbit = false
+	}
+
+	// optional exponential component, which must fit in an int
+	if sc.pos < len(sc.text) {
+		if !sc.marker() {
+			return false, "", "", 0, sc.error(sc.pos, ParseErrorSyntax, nil)
+		}
+		start := sc.pos
+		sc.sign()
+		if _, err = sc.digits(false); err != nil {
+			return false, "", "", 0, err
+		}
+		if exponential, err = strconv.Atoi(sc.text[start:sc.pos]); err != nil {
+			return false, "", "", 0, sc.error(start, ParseErrorExponentRange, err)
+		}
+		if !sc.parser.Limits.checkExponent(exponential) {
+			return false, "", "", 0, sc.error(start, ParseErrorLimit, nil)
+		}
+	}
+
+	if sc.pos < len(sc.text) {
+		return false, "", "", 0, sc.error(sc.pos, ParseErrorSyntax, nil)
+	}
+
+	// apply the unit, if any, which may overflow (detected via saturation)
+	if unit != 0 {
+		e := addSaturating(exponential, unit)
+		if e-unit != exponential {
+			return false, "", "", 0, sc.error(sc.pos, ParseErrorExponentRange, nil)
+		}
+		exponential = e
+		if !sc.parser.Limits.checkExponent(exponential) {
+			return false, "", "", 0, sc.error(sc.pos, ParseErrorLimit, nil)
+		}
+	}
+
+	return signbit, integer, fractional, exponential, nil
+}
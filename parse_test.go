@@ -0,0 +1,317 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func ExampleParseStringErr() {
+	for _, s := range []string{
+		"1,234.5e-2",
+		"",
+		"  ,  ",
+		"12.",
+		"1 x 10 ^ ",
+		"12.34.56",
+		"1e99999999999999999999",
+		"£5",
+	} {
+		fmt.Println(ParseStringErr(s))
+	}
+
+	// Output:
+	// false 1234 5 -2 <nil>
+	// false   0 round: parsing "": empty input at offset 0
+	// false   0 round: parsing "  ,  ": empty input at offset 5
+	// false   0 round: parsing "12.": invalid syntax at offset 3
+	// false   0 round: parsing "1 x 10 ^ ": invalid syntax at offset 9
+	// false   0 round: parsing "12.34.56": invalid syntax at offset 5
+	// false   0 round: parsing "1e99999999999999999999": exponent out of range at offset 2
+	// false   0 round: parsing "£5": invalid syntax at offset 0
+}
+
+func ExampleParseError() {
+	_, err := ParseNumber("1.5e9999999999999999999")
+
+	var pe *ParseError
+	fmt.Println(errors.As(err, &pe))
+	fmt.Println(pe.Input, pe.Offset, pe.Kind)
+	fmt.Println(errors.Is(err, ErrExponentRange), errors.Is(err, ErrSyntax))
+	fmt.Println(errors.Is(err, strconv.ErrRange))
+
+	// Output:
+	// true
+	// 1.5e9999999999999999999 4 exponent out of range
+	// true false
+	// true
+}
+
+func TestParseErrorKind_String(t *testing.T) {
+	if s := ParseErrorKind(0).String(); s != "ParseErrorKind(0)" {
+		t.Error(s)
+	}
+	if (&ParseError{}).Is(nil) {
+		t.Error("unexpected match")
+	}
+}
+
+func TestParseErr(t *testing.T) {
+	if signbit, integer, fractional, exponential, err := ParseErr(-12.5); signbit != true || integer != "12" || fractional != "5" || exponential != 0 || err != nil {
+		t.Error(signbit, integer, fractional, exponential, err)
+	}
+	if signbit, integer, fractional, exponential, err := ParseErr(struct{}{}); signbit || integer != "" || fractional != "" || exponential != 0 || !errors.Is(err, ErrSyntax) {
+		t.Error(signbit, integer, fractional, exponential, err)
+	}
+	if _, integer, _, _, err := (Parser{Units: PercentUnits}).Parse("12%"); integer != "12" || err != nil {
+		t.Error(integer, err)
+	}
+}
+
+func TestParseErrorWithin(t *testing.T) {
+	if err := parseErrorWithin(ErrLimit, "abc", 1); err != ErrLimit {
+		t.Error(err)
+	}
+	err := parseErrorWithin(&ParseError{Input: "", Kind: ParseErrorEmpty}, "$", 1)
+	if e, ok := err.(*ParseError); !ok || *e != (ParseError{Input: "$", Offset: 1, Kind: ParseErrorSyntax}) {
+		t.Error(err)
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	x, err := ParseNumber("-00.0100e3")
+	if err != nil || x != (Number{signbit: true, fractional: "01", exponential: 3}) {
+		t.Error(x, err)
+	}
+	x, err = ParseNumber("-")
+	if !errors.Is(err, ErrSyntax) || x != (Number{}) {
+		t.Error(x, err)
+	}
+}
+
+func TestParseStringErr_offsets(t *testing.T) {
+	type TestCase struct {
+		Input  string
+		Kind   ParseErrorKind
+		Offset int
+	}
+
+	testCases := []TestCase{
+		{
+			Input:  "-",
+			Kind:   ParseErrorSyntax,
+			Offset: 1,
+		},
+		{
+			Input:  "  -   a",
+			Kind:   ParseErrorSyntax,
+			Offset: 7,
+		},
+		{
+			Input:  "1 e",
+			Kind:   ParseErrorSyntax,
+			Offset: 3,
+		},
+		{
+			Input:  "1x10^5x",
+			Kind:   ParseErrorSyntax,
+			Offset: 6,
+		},
+		{
+			Input:  "1x10",
+			Kind:   ParseErrorSyntax,
+			Offset: 1,
+		},
+		{
+			Input:  "\u00a0\u2009",
+			Kind:   ParseErrorEmpty,
+			Offset: 5,
+		},
+		{
+			Input:  "1 * 10 ^ -9999999999999999999",
+			Kind:   ParseErrorExponentRange,
+			Offset: 9,
+		},
+		{
+			Input:  "1\xff",
+			Kind:   ParseErrorSyntax,
+			Offset: 1,
+		},
+	}
+
+	for i, testCase := range testCases {
+		name := fmt.Sprintf("TestParseStringErr_offsets_#%d", i+1)
+
+		signbit, integer, fractional, exponential, err := ParseStringErr(testCase.Input)
+		if signbit || integer != "" || fractional != "" || exponential != 0 {
+			t.Error(name, "unexpected values", signbit, integer, fractional, exponential)
+		}
+
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Error(name, "unexpected error", err)
+			continue
+		}
+
+		if pe.Input != testCase.Input || pe.Kind != testCase.Kind || pe.Offset != testCase.Offset {
+			t.Error(name, "error", pe, "!= expected", testCase.Kind, testCase.Offset)
+		}
+	}
+}
+
+// TestParseString_regex ensures the behavior matches the original regex based implementation.
+func TestParseString_regex(t *testing.T) {
+	var (
+		re    = regexp.MustCompile(`(?i)^((?:)|(?:\+)|(?:-))(\d+)(?:(?:)|(?:\.(\d+)))(?:(?:)|(?:(?:(?:x10\^)|(?:\*10\^)|(?:e))((?:(?:)|(?:\+)|(?:-))\d+)))$`)
+		parse = func(s string) (signbit bool, integer string, fractional string, exponential int, ok bool) {
+			s = strings.Map(
+				func(r rune) rune {
+					if unicode.IsSpace(r) || r == ',' {
+						return -1
+					}
+					return r
+				},
+				s,
+			)
+			sm := re.FindStringSubmatch(s)
+			if len(sm) == 0 {
+				return
+			}
+			signbit = sm[1] == `-`
+			integer = strings.TrimLeft(sm[2], "0")
+			fractional = strings.TrimRight(sm[3], "0")
+			if integer == "" && fractional == "" {
+				signbit = false
+			}
+			if sm[4] != "" {
+				v, err := strconv.Atoi(sm[4])
+				if err != nil {
+					return false, "", "", 0, false
+				}
+				exponential = v
+			}
+			ok = true
+			return
+		}
+		alphabet = []string{"0", "1", "5", "9", ".", "-", "+", "e", "E", "x", "X", "*", "10^", "^", " ", ",", "\t", "a", "é"}
+	)
+
+	rng := rand.New(rand.NewSource(1))
+	for x := 0; x < 100000; x++ {
+		var b strings.Builder
+		for l := rng.Intn(12); l > 0; l-- {
+			b.WriteString(alphabet[rng.Intn(len(alphabet))])
+		}
+		s := b.String()
+
+		as, ai, af, ae, aok := ParseString(s)
+		bs, bi, bf, be, bok := parse(s)
+
+		if as != bs || ai != bi || af != bf || ae != be || aok != bok {
+			t.Fatalf("%q: (%v,%q,%q,%v,%v) != expected (%v,%q,%q,%v,%v)", s, as, ai, af, ae, aok, bs, bi, bf, be, bok)
+		}
+	}
+}
+
+func ExampleParser_lenient() {
+	p := Parser{Lenient: true}
+	for _, s := range []string{".5", "-.25", "5.", "+5.e-3", "-.5x10^2", "0.", ".0", ".", "-.", ".e5"} {
+		fmt.Println(p.ParseString(s))
+	}
+
+	// Output:
+	// false  5 0 <nil>
+	// true  25 0 <nil>
+	// false 5  0 <nil>
+	// false 5  -3 <nil>
+	// true  5 2 <nil>
+	// false   0 <nil>
+	// false   0 <nil>
+	// false   0 round: parsing ".": invalid syntax at offset 1
+	// false   0 round: parsing "-.": invalid syntax at offset 2
+	// false   0 round: parsing ".e5": invalid syntax at offset 1
+}
+
+func TestParser_lenient(t *testing.T) {
+	var (
+		signs     = []string{"", "+", "-"}
+		integers  = []string{"", "0", "12"}
+		points    = []string{"", "."}
+		fractions = []string{"", "0", "34"}
+		exponents = []string{"", "e5", "E-5", "x10^+5", "*10^5"}
+		p         = Parser{Lenient: true}
+	)
+	for _, sign := range signs {
+		for _, integer := range integers {
+			for _, point := range points {
+				for _, fraction := range fractions {
+					if point == "" && fraction != "" {
+						// fractional digits without a point would just be integer digits
+						continue
+					}
+					for _, exponent := range exponents {
+						s := sign + integer + point + fraction + exponent
+
+						// the lenient result should match parsing with any missing digits filled in with zeros
+						normalised := integer
+						if normalised == "" {
+							normalised = "0"
+						}
+						if point != "" {
+							normalised += "."
+							if fraction == "" {
+								normalised += "0"
+							}
+						}
+						normalised = sign + normalised + fraction + exponent
+
+						as, ai, af, ae, aerr := p.ParseString(s)
+
+						valid := integer != "" || (point != "" && fraction != "")
+						if !valid {
+							if aerr == nil {
+								t.Error(s, "expected error")
+							}
+							continue
+						}
+
+						bs, bi, bf, be, berr := ParseStringErr(normalised)
+						if berr != nil {
+							t.Fatal(normalised, berr)
+						}
+
+						if aerr != nil || as != bs || ai != bi || af != bf || ae != be {
+							t.Error(s, "!=", normalised, as, ai, af, ae, aerr)
+						}
+
+						// the default parser should only accept it if it didn't need normalising
+						if _, _, _, _, err := ParseStringErr(s); (err == nil) != (s == normalised) {
+							t.Error(s, "unexpected default result", err)
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,185 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Quo returns x/y rounded to n decimal places using the given mode, and exact, which will be true only if no
+// (non-zero) remainder was discarded, i.e. the result is exactly x/y. It returns ErrDivisionByZero if y is zero,
+// ErrRoundingMode if the mode is not valid, or an error wrapping ErrLimit if the result would have more digits than
+// the max int.
+//
+// NOTE: like Apply, n may be negative, to round to the left of the decimal point, and the time and memory used is
+// proportional to the number of digits in the result, so use Limits to restrict untrusted input.
+func (x Number) Quo(y Number, n int, mode RoundingMode) (q Number, exact bool, err error) {
+	if !mode.valid() {
+		return Number{}, false, ErrRoundingMode
+	}
+	if !x.isFinite() || !y.isFinite() {
+		switch {
+		case x.IsNaN() || y.IsNaN() || (x.IsInf(0) && y.IsInf(0)):
+			return NaN(), true, nil
+		case x.IsInf(0):
+			return x.signed(x.signbit != y.signbit), true, nil
+		default:
+			// finite/Inf
+			return Number{}, true, nil
+		}
+	}
+	if y.IsZero() {
+		return Number{}, false, ErrDivisionByZero
+	}
+
+	a, b := x.coefficient(), y.coefficient()
+	if len(a.digits) == 0 {
+		return Number{}, true, nil
+	}
+	signbit := a.signbit != b.signbit
+
+	// the quotient is less than 10 ^ (p+1), so if p+1 <= -n-1, it is less than a tenth of the last retained digit,
+	// and we can round it without dividing, which avoids padding the denominator with (up to) -p zeros
+	p := quoExponent(a, b)
+	if t := new(big.Int).Add(p, big.NewInt(int64(n))); t.Add(t, big.NewInt(1)).Sign() < 0 {
+		var digits []byte
+		if roundMode(mode, signbit, nil, []rune("1")) {
+			digits = []byte("1")
+		}
+		return quoNumber(signbit, digits, n), false, nil
+	}
+
+	// x/y = (a.digits/b.digits) x 10 ^ (a.exp-b.exp), and we want an integer quotient with an exp of -n, so we need
+	// to scale the numerator (or denominator) by 10 ^ shift, where shift >= -(len(a.digits)+1), given p+n >= -1
+	shift := big.NewInt(int64(a.exp))
+	shift.Sub(shift, big.NewInt(int64(b.exp))).Add(shift, big.NewInt(int64(n)))
+	if shift.Cmp(big.NewInt(int64(maxInt-len(a.digits)))) >= 0 {
+		return Number{}, false, fmt.Errorf("round: quotient exceeds %d digits: %w", maxInt, ErrLimit)
+	}
+	numerator, denominator := a.digits, b.digits
+	if shift := int(shift.Int64()); shift > 0 {
+		numerator = appendZeros(numerator, shift)
+	} else if shift < 0 {
+		denominator = appendZeros(denominator, -shift)
+	}
+
+	quotient, remainder := divDigits(numerator, denominator)
+
+	// the discarded part is remainder/denominator, which we classify relative to one half, representing it as
+	// (short) fractional digits that will be treated the same way by roundMode
+	var fractional []rune
+	if len(remainder) != 0 {
+		switch cmpDigits(addDigits(remainder, remainder), denominator) {
+		case -1:
+			fractional = []rune("1")
+		case 0:
+			fractional = []rune("5")
+		default:
+			fractional = []rune("6")
+		}
+	}
+
+	integer := []rune(string(quotient))
+	if roundMode(mode, signbit, integer, fractional) {
+		integer = incrementInteger(integer)
+	}
+
+	return quoNumber(signbit, []byte(string(integer)), n), len(remainder) == 0, nil
+}
+
+// QuoSignificant is like Quo, but rounds to k significant digits, instead of to n decimal places, and returns
+// ErrSignificantDigits if k is less than 1, note that, like Mul, it overflows to an infinity if the exponent of the
+// result would exceed the max int, and returns zero if rounding to k digits would need more than max int decimal
+// places.
+func (x Number) QuoSignificant(y Number, k int, mode RoundingMode) (q Number, exact bool, err error) {
+	if !mode.valid() {
+		return Number{}, false, ErrRoundingMode
+	}
+	if k < 1 {
+		return Number{}, false, ErrSignificantDigits
+	}
+	if y.IsZero() || !x.isFinite() || !y.isFinite() {
+		return x.Quo(y, 0, mode)
+	}
+
+	a, b := x.coefficient(), y.coefficient()
+	if len(a.digits) == 0 {
+		return x.Quo(y, 0, mode)
+	}
+
+	// keeping k digits, starting from the one at 10 ^ p, is the same as rounding to k-1-p decimal places, which is
+	// out of range if the exponent of the result would exceed the range of int, see Number
+	n := new(big.Int).Sub(big.NewInt(int64(k-1)), quoExponent(a, b))
+	switch {
+	case n.Cmp(big.NewInt(int64(minInt))) < 0:
+		return Inf(1).signed(a.signbit != b.signbit), false, nil
+	case n.Cmp(big.NewInt(int64(maxInt))) > 0:
+		return Number{}, false, nil
+	}
+	return x.Quo(y, int(n.Int64()), mode)
+}
+
+// quoExponent returns the exponent p of the most significant digit of the quotient a/b, for non-zero a and b, such
+// that the quotient is in [10 ^ p, 10 ^ (p+1)), which may exceed the range of int.
+func quoExponent(a, b coefficient) *big.Int {
+	// this depends on the positions of the most significant digits of a and b, and is one lower if the digits of a
+	// (aligned) are less than the digits of b
+	p := big.NewInt(int64(a.exp))
+	p.Sub(p, big.NewInt(int64(b.exp))).Add(p, big.NewInt(int64(len(a.digits)-len(b.digits))))
+	if cmpAligned(a.digits, b.digits) < 0 {
+		p.Sub(p, big.NewInt(1))
+	}
+	return p
+}
+
+// quoNumber returns digits x 10 ^ -n, which is exact even if n is the min int.
+func quoNumber(signbit bool, digits []byte, n int) Number {
+	if n == minInt {
+		if len(digits) != 0 {
+			digits = append(digits, '0')
+		}
+		return coefficient{signbit: signbit, digits: digits, exp: maxInt}.number()
+	}
+	return coefficient{signbit: signbit, digits: digits, exp: -n}.number()
+}
+
+// appendZeros returns a copy of digits with n zeros appended.
+func appendZeros(digits []byte, n int) []byte {
+	result := make([]byte, len(digits), len(digits)+n)
+	copy(result, digits)
+	for i := 0; i < n; i++ {
+		result = append(result, '0')
+	}
+	return result
+}
+
+// divDigits performs long division of two uints expressed as digits, returning the quotient and remainder, note
+// that the denominator must not be zero, or have leading zeros.
+func divDigits(numerator, denominator []byte) (quotient []byte, remainder []byte) {
+	quotient = make([]byte, 0, len(numerator))
+	for _, d := range numerator {
+		remainder = trimDigits(append(remainder, d))
+		q := byte('0')
+		for cmpDigits(remainder, denominator) >= 0 {
+			remainder = subDigits(remainder, denominator)
+			q++
+		}
+		quotient = append(quotient, q)
+	}
+	return trimDigits(quotient), remainder
+}
@@ -0,0 +1,193 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func ExampleNumber_Quo() {
+	one, _ := ParseNumber("1")
+	three, _ := ParseNumber("3")
+	eight, _ := ParseNumber("8")
+
+	fmt.Println(one.Quo(three, 5, RoundHalfEven))
+	fmt.Println(one.Neg().Quo(three, 2, RoundFloor))
+	fmt.Println(one.Quo(eight, 2, RoundHalfEven))
+	fmt.Println(one.Quo(eight, 3, RoundHalfEven))
+	fmt.Println(eight.Quo(three, -1, RoundHalfAwayFromZero))
+	fmt.Println(one.Quo(Number{}, 2, RoundHalfEven))
+
+	// Output:
+	// 0.33333 false <nil>
+	// -0.34 false <nil>
+	// 0.12 false <nil>
+	// 0.125 true <nil>
+	// 0 false <nil>
+	// 0 false round: division by zero
+}
+
+func ExampleNumber_QuoSignificant() {
+	x, _ := ParseNumber("2")
+	for _, s := range []string{"3", "3e-10", "-7e20", "0.2", "1.9"} {
+		y, _ := ParseNumber(s)
+		fmt.Println(x.QuoSignificant(y, 3, RoundHalfEven))
+	}
+
+	// Output:
+	// 0.667 false <nil>
+	// 6670000000 false <nil>
+	// -0.00000000000000000000286 false <nil>
+	// 10 true <nil>
+	// 1.05 false <nil>
+}
+
+func TestNumber_Quo_errors(t *testing.T) {
+	x, _ := ParseNumber("1")
+	if q, exact, err := x.Quo(x, 0, RoundingMode(99)); q != (Number{}) || exact || err != ErrRoundingMode {
+		t.Error(q, exact, err)
+	}
+	if q, exact, err := x.QuoSignificant(Number{}, 2, RoundHalfEven); q != (Number{}) || exact || !errors.Is(err, ErrDivisionByZero) {
+		t.Error(q, exact, err)
+	}
+	if q, exact, err := (Number{}).QuoSignificant(x, 2, RoundHalfEven); q != (Number{}) || !exact || err != nil {
+		t.Error(q, exact, err)
+	}
+	nine, _ := ParseNumber("9")
+	for _, k := range []int{0, -1} {
+		if q, exact, err := nine.QuoSignificant(x, k, RoundHalfEven); q != (Number{}) || exact || err != ErrSignificantDigits {
+			t.Error(k, q, exact, err)
+		}
+	}
+	if q, exact, err := nine.QuoSignificant(x, 0, RoundingMode(99)); q != (Number{}) || exact || err != ErrRoundingMode {
+		t.Error(q, exact, err)
+	}
+}
+
+func TestNumber_Quo(t *testing.T) {
+	var (
+		rng    = rand.New(rand.NewSource(1))
+		random = func() string {
+			s := fmt.Sprintf("%d.%de%d", rng.Int63n(1<<uint(rng.Intn(40)+1)), rng.Int63n(1<<uint(rng.Intn(40)+1)), rng.Intn(21)-10)
+			if rng.Intn(2) == 0 {
+				s = "-" + s
+			}
+			return s
+		}
+		modes = []RoundingMode{RoundHalfEven, RoundFloor, RoundCeiling, RoundDown}
+	)
+
+	for i := 0; i < 2000; i++ {
+		x, _ := ParseNumber(random())
+		y, _ := ParseNumber(random())
+		if y.IsZero() {
+			continue
+		}
+		// NOTE: this includes quotients that are rounded without dividing, as they are below a tenth of 10 ^ -n
+		n := rng.Intn(61) - 30
+		mode := modes[rng.Intn(len(modes))]
+
+		q, exact, err := x.Quo(y, n, mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// compute the expected result using big.Rat, rounding with the string based implementation
+		r, _ := new(big.Rat).SetString(x.String())
+		d, _ := new(big.Rat).SetString(y.String())
+		r.Quo(r, d)
+		// NOTE: an extra 64 digits of precision avoids double rounding issues, for these (random) inputs
+		e, _ := NewNumber(ParseString(r.FloatString(n + 64)))
+		e, _ = e.RoundMode(n, mode)
+
+		if q.Cmp(e) != 0 {
+			t.Fatal(x, "/", y, "to", n, mode, "=", q, "!= expected", e)
+		}
+
+		if isExact := r.Cmp(mustRat(q)) == 0; isExact != exact {
+			t.Fatal(x, "/", y, "to", n, mode, "exact", exact, "!= expected", isExact)
+		}
+	}
+}
+
+func TestNumber_Quo_exponentRange(t *testing.T) {
+	parse := func(s string) Number {
+		x, err := ParseNumber(s)
+		if err != nil {
+			t.Fatal(s, err)
+		}
+		return x
+	}
+	for _, tc := range []struct {
+		X, Y   string
+		N      int
+		Mode   RoundingMode
+		Output Number
+		Exact  bool
+	}{
+		{"1", "1e9223372036854775807", 2, RoundHalfEven, Number{}, false},
+		{"1", "1e9223372036854775807", 2, RoundUp, parse("0.01"), false},
+		{"-1", "1e999999999", 2, RoundFloor, parse("-0.01"), false},
+		{"1", "1e999999999", 2, RoundHalfEven, Number{}, false},
+		{"0", "1e-9223372036854775808", 2, RoundHalfEven, Number{}, true},
+		{"1e9223372036854775807", "1e9223372036854775807", 0, RoundHalfEven, parse("1"), true},
+		{"1e-9223372036854775808", "1e9223372036854775807", 2, RoundCeiling, parse("0.01"), false},
+		{"5", "1", minInt, RoundUp, parse("10e9223372036854775807"), false},
+		{"5", "1", minInt, RoundDown, Number{}, false},
+		{"12e9223372036854775807", "1", minInt, RoundHalfEven, parse("10e9223372036854775807"), false},
+		{"12e9223372036854775807", "10", -(maxInt - 1), RoundHalfEven, parse("12e9223372036854775806"), true},
+		{"1", "3", -1, RoundUp, parse("10"), false},
+	} {
+		q, exact, err := parse(tc.X).Quo(parse(tc.Y), tc.N, tc.Mode)
+		if err != nil || exact != tc.Exact || q.Cmp(tc.Output) != 0 {
+			t.Error(tc.X, tc.Y, tc.N, tc.Mode, q.integer, q.fractional, q.exponential, exact, err)
+		}
+	}
+	if _, _, err := parse("1e9223372036854775807").Quo(parse("1e-9223372036854775808"), 0, RoundHalfEven); !errors.Is(err, ErrLimit) {
+		t.Error(err)
+	}
+	if _, _, err := parse("1").Quo(parse("1"), maxInt, RoundHalfEven); !errors.Is(err, ErrLimit) {
+		t.Error(err)
+	}
+	for _, tc := range []struct {
+		X, Y   string
+		Output Number
+	}{
+		{"1e9223372036854775807", "1e-9223372036854775808", Inf(1)},
+		{"-1e9223372036854775807", "1e-9223372036854775808", Inf(-1)},
+		{"1e-9223372036854775808", "1e9223372036854775807", Number{}},
+		{"1e9223372036854775807", "3", parse("3.33e9223372036854775806")},
+		{"2e-9223372036854775804", "3", parse("0.667e-9223372036854775804")},
+		{"2e-9223372036854775805", "3", Number{}},
+	} {
+		if q, exact, err := parse(tc.X).QuoSignificant(parse(tc.Y), 3, RoundHalfEven); err != nil || exact || q.Class() != tc.Output.Class() || q.Cmp(tc.Output) != 0 {
+			t.Error(tc.X, tc.Y, q.Class(), q.integer, q.fractional, q.exponential, exact, err)
+		}
+	}
+}
+
+func mustRat(x Number) *big.Rat {
+	r, ok := new(big.Rat).SetString(x.String())
+	if !ok {
+		panic(x)
+	}
+	return r
+}
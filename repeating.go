@@ -0,0 +1,312 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+)
+
+// Repeating is the decimal expansion of a rational number, which either terminates, or ends with a repeating group
+// of digits (the repetend), e.g. 1/6 is 0.1666..., which has an Integer of "", a Fractional of "1", and a Repetend
+// of "6".
+//
+// Values produced by this package are always in the canonical form, i.e. Integer has no leading zeros, and the
+// Fractional and Repetend are as short as possible, so 0.(9) is represented as 1, and 0.1(66) as 0.1(6).
+type Repeating struct {
+	// Signbit is true if the number is negative, it is always false for zero.
+	Signbit bool
+
+	// Integer contains the integer digits, with leading zeros stripped (an empty string representing zero).
+	Integer string
+
+	// Fractional contains the fractional digits that precede the repetend.
+	Fractional string
+
+	// Repetend contains the digits that repeat forever, which is empty if the expansion terminates.
+	Repetend string
+}
+
+// RepeatingNotation identifies a format for Repeating, see Repeating.Format.
+type RepeatingNotation int
+
+const (
+	// RepeatingParentheses formats the repetend in parentheses, e.g. 0.1(6).
+	RepeatingParentheses RepeatingNotation = iota
+
+	// RepeatingOverline formats the repetend with a combining overline (U+0305) after each digit, e.g. 0.16̅.
+	RepeatingOverline
+)
+
+// overline is the combining character used by RepeatingOverline.
+const overline = '̅'
+
+// NewRepeatingBigRat returns the decimal expansion of r, or an error wrapping ErrLimit if there would be more than
+// max fractional digits (including the repetend), where max <= 0 means there is no limit.
+//
+// NOTE: the repetend of a/b may be up to b-1 digits long, so max should be used to restrict untrusted input.
+func NewRepeatingBigRat(r *big.Rat, max int) (Repeating, error) {
+	num, den := new(big.Int).Abs(r.Num()), r.Denom()
+
+	// the length of the non-repeating fractional digits is the larger of the powers of 2 and 5 in the (reduced)
+	// denominator, and after that the remainder will recur
+	var (
+		twos  = int(den.TrailingZeroBits())
+		fives int
+		q, m  big.Int
+		ten   = big.NewInt(10)
+		five  = big.NewInt(5)
+	)
+	for d := new(big.Int).Rsh(den, uint(twos)); ; fives++ {
+		if q.QuoRem(d, five, &m); m.Sign() != 0 {
+			break
+		}
+		d.Set(&q)
+	}
+	k := twos
+	if fives > k {
+		k = fives
+	}
+
+	var (
+		result     = Repeating{Signbit: r.Sign() < 0}
+		rem        = new(big.Int)
+		fractional []byte
+	)
+	integer, _ := new(big.Int).QuoRem(num, den, rem)
+	if integer.Sign() != 0 {
+		result.Integer = integer.String()
+	}
+
+	// next performs one step of long division, appending the digit
+	next := func() error {
+		if max > 0 && len(fractional) >= max {
+			return fmt.Errorf("round: repeating decimal exceeds %d digits: %w", max, ErrLimit)
+		}
+		rem.Mul(rem, ten)
+		q.QuoRem(rem, den, rem)
+		fractional = append(fractional, byte(q.Int64())+'0')
+		return nil
+	}
+
+	for i := 0; i < k; i++ {
+		if err := next(); err != nil {
+			return Repeating{}, err
+		}
+	}
+	result.Fractional = string(fractional)
+
+	if rem.Sign() != 0 {
+		start := new(big.Int).Set(rem)
+		for {
+			if err := next(); err != nil {
+				return Repeating{}, err
+			}
+			if rem.Cmp(start) == 0 {
+				break
+			}
+		}
+		result.Repetend = string(fractional[k:])
+	}
+
+	return result, nil
+}
+
+// QuoRepeating returns the exact quotient x/y as a repeating decimal, see NewRepeatingBigRat, or ErrDivisionByZero,
+// or ErrNotFinite if x or y is an infinity or NaN.
+func (x Number) QuoRepeating(y Number, max int) (Repeating, error) {
+	if !x.isFinite() || !y.isFinite() {
+		return Repeating{}, ErrNotFinite
+	}
+	if y.IsZero() {
+		return Repeating{}, ErrDivisionByZero
+	}
+	return NewRepeatingBigRat(new(big.Rat).Quo(x.coefficient().rat(), y.coefficient().rat()), max)
+}
+
+// String formats the number using RepeatingParentheses.
+func (r Repeating) String() string {
+	return r.Format(RepeatingParentheses)
+}
+
+// Format formats the number using the given notation, omitting the decimal point if there are no fractional
+// digits, e.g. "-1.2(34)" or "3", note that the output can be parsed by Parser.ParseRepeating.
+func (r Repeating) Format(notation RepeatingNotation) string {
+	var b strings.Builder
+	if r.Signbit {
+		b.WriteByte('-')
+	}
+	if r.Integer == "" {
+		b.WriteByte('0')
+	} else {
+		b.WriteString(r.Integer)
+	}
+	if r.Fractional == "" && r.Repetend == "" {
+		return b.String()
+	}
+	b.WriteByte('.')
+	b.WriteString(r.Fractional)
+	if r.Repetend != "" {
+		switch notation {
+		case RepeatingOverline:
+			for _, d := range r.Repetend {
+				b.WriteRune(d)
+				b.WriteRune(overline)
+			}
+		default:
+			b.WriteByte('(')
+			b.WriteString(r.Repetend)
+			b.WriteByte(')')
+		}
+	}
+	return b.String()
+}
+
+// BigRat returns the exact value as a *big.Rat.
+func (r Repeating) BigRat() *big.Rat {
+	prefix, _ := NewNumber(r.Signbit, r.Integer, r.Fractional, 0, true)
+	v := prefix.coefficient().rat()
+	if r.Repetend == "" {
+		return v
+	}
+	// 0.00(R) = R / (10^len(F) x (10^len(R) - 1))
+	repetend, _ := new(big.Int).SetString(r.Repetend, 10)
+	den := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(len(r.Repetend))), nil)
+	den.Sub(den, big.NewInt(1))
+	den.Mul(den, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(len(r.Fractional))), nil))
+	tail := new(big.Rat).SetFrac(repetend, den)
+	if r.Signbit {
+		tail.Neg(tail)
+	}
+	return v.Add(v, tail)
+}
+
+// Round returns the number rounded to n decimal places using mode, where exact will be false if rounding changed
+// the value (which is always the case for a repeating decimal), or false for ok if mode is not valid.
+func (r Repeating) Round(n int, mode RoundingMode) (x Number, exact bool, ok bool) {
+	// expand far enough that the discarded digits include a full repetend, so they are correctly classified as
+	// being non-zero, and never exactly half
+	fractional := r.Fractional
+	if r.Repetend != "" {
+		var b strings.Builder
+		b.WriteString(fractional)
+		for b.Len() < n+1+2*len(r.Repetend) || b.Len() < len(r.Fractional)+len(r.Repetend) {
+			b.WriteString(r.Repetend)
+		}
+		fractional = b.String()
+	}
+	v, ok := NewNumberRunes(ApplyMode(Runes(r.Signbit, r.Integer, fractional, 0, true))(n, mode))
+	if !ok {
+		return Number{}, false, false
+	}
+	if r.Repetend != "" {
+		return v, false, true
+	}
+	u, _ := NewNumber(r.Signbit, r.Integer, r.Fractional, 0, true)
+	return v, v.Cmp(u) == 0, true
+}
+
+// ParseRepeating parses a repeating decimal in either of the RepeatingNotation formats, e.g. "0.(3)", "-1.2(34)",
+// or "0.16̅", or any input accepted by ParseNumber (with an exponential of 0), returning it in canonical form, e.g.
+// "0.(9)" is returned as 1.
+//
+// The digits prior to the repetend are parsed in the same way as ParseNumber, and must include the decimal
+// separator, but not an exponential component, and the repetend must contain only ASCII digits.
+func (p Parser) ParseRepeating(s string) (Repeating, error) {
+	syntax := func(offset int) error {
+		return &ParseError{Input: s, Offset: offset, Kind: ParseErrorSyntax}
+	}
+
+	locale := p.locale()
+	trimmed := strings.TrimRightFunc(s, locale.space)
+
+	// locate the repetend, which must be at the end of the input, start is the offset of the first byte of the
+	// notation (the parenthesis, or the first overlined digit)
+	var (
+		start    = len(s)
+		repetend string
+	)
+	if i := strings.IndexByte(trimmed, '('); i >= 0 {
+		start = i
+		if !strings.HasSuffix(trimmed, ")") {
+			return Repeating{}, syntax(len(trimmed))
+		}
+		repetend = trimmed[i+1 : len(trimmed)-1]
+		for j, c := range []byte(repetend) {
+			if c < '0' || c > '9' {
+				return Repeating{}, syntax(i + 1 + j)
+			}
+		}
+		if repetend == "" {
+			return Repeating{}, syntax(i + 1)
+		}
+	} else if i := strings.IndexRune(trimmed, overline); i >= 0 {
+		if i == 0 {
+			return Repeating{}, syntax(0)
+		}
+		start = i - 1
+		var b strings.Builder
+		for j := start; j < len(trimmed); j += 1 + utf8.RuneLen(overline) {
+			if c := trimmed[j]; c < '0' || c > '9' || !strings.HasPrefix(trimmed[j+1:], string(overline)) {
+				return Repeating{}, syntax(j)
+			}
+			b.WriteByte(trimmed[j])
+		}
+		repetend = b.String()
+	}
+
+	// the prefix must contain a decimal separator, followed only by digits, if there's a repetend
+	prefix := strings.TrimLeftFunc(s[:start], locale.space)
+	offset := start - len(prefix)
+	decimal := strings.LastIndex(prefix, string(locale.decimal()))
+	if repetend != "" && decimal < 0 {
+		return Repeating{}, syntax(start)
+	}
+	digits := 0
+	if decimal >= 0 {
+		for j, c := range []byte(prefix[decimal+utf8.RuneLen(locale.decimal()):]) {
+			if c < '0' || c > '9' {
+				return Repeating{}, syntax(offset + decimal + utf8.RuneLen(locale.decimal()) + j)
+			}
+			digits++
+		}
+		if digits == 0 {
+			// allow a trailing decimal separator like "0.(3)", without requiring Lenient
+			prefix = prefix[:decimal]
+		}
+	}
+
+	x, err := p.ParseNumber(prefix)
+	if err != nil {
+		return Repeating{}, parseErrorWithin(err, s, offset)
+	}
+	if !x.isFinite() || x.Exponential() != 0 {
+		// the exponential is only possible without a decimal separator, e.g. "1e3", which could be supported, but
+		// it would be inconsistent with the repeating notations
+		return Repeating{}, syntax(offset)
+	}
+
+	r := Repeating{
+		Signbit:    strings.HasPrefix(prefix, "-"),
+		Integer:    x.Integer(),
+		Fractional: x.Fractional() + strings.Repeat("0", digits-len(x.Fractional())),
+		Repetend:   repetend,
+	}
+	return NewRepeatingBigRat(r.BigRat(), 0)
+}
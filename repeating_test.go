@@ -0,0 +1,180 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func ExampleNewRepeatingBigRat() {
+	for _, r := range []*big.Rat{big.NewRat(1, 3), big.NewRat(1, 6), big.NewRat(-22, 7), big.NewRat(5, 4), big.NewRat(1, 1)} {
+		v, _ := NewRepeatingBigRat(r, 0)
+		fmt.Printf("%s %s %q %q %q\n", v, v.Format(RepeatingOverline), v.Integer, v.Fractional, v.Repetend)
+	}
+
+	// Output:
+	// 0.(3) 0.3̅ "" "" "3"
+	// 0.1(6) 0.16̅ "" "1" "6"
+	// -3.(142857) -3.1̅4̅2̅8̅5̅7̅ "3" "" "142857"
+	// 1.25 1.25 "1" "25" ""
+	// 1 1 "1" "" ""
+}
+
+func ExampleNumber_QuoRepeating() {
+	x, _ := ParseNumber("1")
+	y, _ := ParseNumber("0.07")
+	r, err := x.QuoRepeating(y, 0)
+	fmt.Println(r, err)
+	fmt.Println(r.Round(3, RoundHalfEven))
+
+	// Output:
+	// 14.(285714) <nil>
+	// 14.286 false true
+}
+
+func ExampleParser_ParseRepeating() {
+	for _, s := range []string{"0.(3)", "0.1(6)", "-1.2(34)", "0.16̅", "0.(9)", "0.1(66)", "1.5", "12"} {
+		r, err := Parser{}.ParseRepeating(s)
+		fmt.Println(r, err)
+	}
+
+	// Output:
+	// 0.(3) <nil>
+	// 0.1(6) <nil>
+	// -1.2(34) <nil>
+	// 0.1(6) <nil>
+	// 1 <nil>
+	// 0.1(6) <nil>
+	// 1.5 <nil>
+	// 12 <nil>
+}
+
+func TestNewRepeatingBigRat_roundTrip(t *testing.T) {
+	for num := int64(-60); num <= 60; num++ {
+		for den := int64(1); den <= 120; den++ {
+			r := big.NewRat(num, den)
+			v, err := NewRepeatingBigRat(r, 0)
+			if err != nil {
+				t.Fatal(r, err)
+			}
+			if v.BigRat().Cmp(r) != 0 {
+				t.Fatal(r, v)
+			}
+			for _, notation := range []RepeatingNotation{RepeatingParentheses, RepeatingOverline} {
+				s := v.Format(notation)
+				w, err := Parser{}.ParseRepeating(s)
+				if err != nil || w != v {
+					t.Fatal(r, s, w, err)
+				}
+			}
+			// rounding must match division
+			x, _ := NewNumber(num < 0, String(new(big.Int).Abs(big.NewInt(num))), "", 0, true)
+			y, _ := NewNumber(false, String(den), "", 0, true)
+			for _, mode := range []RoundingMode{RoundHalfEven, RoundHalfUp, RoundCeiling, RoundDown, Round05Up} {
+				for _, n := range []int{-1, 0, 2, 5} {
+					a, exactA, ok := v.Round(n, mode)
+					b, exactB, err := x.Quo(y, n, mode)
+					if !ok || err != nil || a.Cmp(b) != 0 || exactA != exactB {
+						t.Fatal(r, mode, n, a, exactA, b, exactB)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestNewRepeatingBigRat_limit(t *testing.T) {
+	// 1/97 has a period of 96
+	if _, err := NewRepeatingBigRat(big.NewRat(1, 97), 96); err != nil {
+		t.Error(err)
+	}
+	if _, err := NewRepeatingBigRat(big.NewRat(1, 97), 95); !errors.Is(err, ErrLimit) {
+		t.Error(err)
+	}
+	if _, err := NewRepeatingBigRat(big.NewRat(1, 1024), 9); !errors.Is(err, ErrLimit) {
+		t.Error(err)
+	}
+}
+
+func TestRepeating_Round_invalidMode(t *testing.T) {
+	r, err := (Parser{}).ParseRepeating("0.(3)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x, exact, ok := r.Round(2, RoundingMode(-1)); ok || exact || x != (Number{}) {
+		t.Error(x, exact, ok)
+	}
+}
+
+func TestNumber_QuoRepeating_errors(t *testing.T) {
+	one, _ := ParseNumber("1")
+	if _, err := one.QuoRepeating(Number{}, 0); err != ErrDivisionByZero {
+		t.Error(err)
+	}
+	if _, err := one.QuoRepeating(Inf(1), 0); err != ErrNotFinite {
+		t.Error(err)
+	}
+	if _, err := NaN().QuoRepeating(one, 0); err != ErrNotFinite {
+		t.Error(err)
+	}
+}
+
+func TestParser_ParseRepeating(t *testing.T) {
+	for _, tc := range []struct {
+		Input  string
+		Output string
+		Err    string
+	}{
+		{" -0.(3) ", "-0.(3)", ""},
+		{"-0.(0)", "0", ""},
+		{"1.(9)", "2", ""},
+		{"0.00(142857)", "0.00(142857)", ""},
+		{"0.10(6)", "0.10(6)", ""},
+		{"1,234.5(6)", "1234.5(6)", ""},
+		{"0.(3", "", `round: parsing "0.(3": invalid syntax at offset 4`},
+		{"0.()", "", `round: parsing "0.()": invalid syntax at offset 3`},
+		{"0.(3a)", "", `round: parsing "0.(3a)": invalid syntax at offset 4`},
+		{"(3)", "", `round: parsing "(3)": invalid syntax at offset 0`},
+		{"1(3)", "", `round: parsing "1(3)": invalid syntax at offset 1`},
+		{".(3)", "", `round: parsing ".(3)": invalid syntax at offset 0`},
+		{"1.5e1(3)", "", `round: parsing "1.5e1(3)": invalid syntax at offset 3`},
+		{"1e1", "", `round: parsing "1e1": invalid syntax at offset 0`},
+		{"x.(3)", "", `round: parsing "x.(3)": invalid syntax at offset 0`},
+		{"̅", "", `round: parsing "̅": invalid syntax at offset 0`},
+		{"0.1̅2", "", `round: parsing "0.1̅2": invalid syntax at offset 5`},
+		{"", "", `round: parsing "": empty input at offset 0`},
+		{"inf", "", `round: parsing "inf": invalid syntax at offset 0`},
+	} {
+		r, err := Parser{}.ParseRepeating(tc.Input)
+		if err != nil {
+			if err.Error() != tc.Err {
+				t.Errorf("%q: unexpected error: %v", tc.Input, err)
+			}
+			continue
+		}
+		if tc.Err != "" || r.String() != tc.Output {
+			t.Errorf("%q: unexpected result: %s", tc.Input, r)
+		}
+	}
+	r, err := Parser{Locale: &LocaleDE}.ParseRepeating("1.234,(3)")
+	if err != nil || r.String() != "1234.(3)" {
+		t.Error(r, err)
+	}
+}
@@ -20,11 +20,7 @@ package round
 
 import (
 	"errors"
-	"fmt"
-	"regexp"
 	"strconv"
-	"strings"
-	"unicode"
 )
 
 const (
@@ -38,6 +34,10 @@ const (
 	// https://en.wikipedia.org/wiki/Double-precision_floating-point_format#IEEE_754_double-precision_binary_floating-point_format:_binary64
 	FormatFloat64 = `%.17g`
 
+	// FormatRatDigits is the number of significant digits that String uses for a *big.Rat with a decimal expansion
+	// that doesn't terminate, e.g. 1/3, rounded half to even, which matches the precision of IEEE 754 decimal128.
+	FormatRatDigits = 34
+
 	// MinExponentFloat64 is the smallest valid exponent (biased) in IEEE 754 binary64 format
 	MinExponentFloat64 = -1022
 
@@ -46,16 +46,18 @@ const (
 )
 
 // String converts a value to a string, handling special cases for floating points in order to apply FormatFloat32 and
-// FormatFloat64, otherwise by default just using fmt.Sprint.
+// FormatFloat64, as well as the other numeric types supported by Parse, otherwise by default just using fmt.Sprint.
+//
+// The supported types are:
+// - all integer and floating point kinds, including named types like time.Duration, using the underlying value
+// - complex64 and complex128, with a zero imaginary part (anything else will fail to parse)
+// - json.Number, which is used as-is
+// - *big.Int, *big.Float (exactly, see NewNumberBigFloat), and *big.Rat, which is exact if the denominator has no
+//   prime factors other than 2 and 5, otherwise it is rounded to FormatRatDigits significant digits
+// - Number, and any other implementation of Decimaler
+// - pointers to any of the above, which are dereferenced (if not nil)
 func String(v interface{}) string {
-	switch value := v.(type) {
-	case float32:
-		return fmt.Sprintf(FormatFloat32, value)
-	case float64:
-		return fmt.Sprintf(FormatFloat64, value)
-	default:
-		return fmt.Sprint(v)
-	}
+	return formatValue(v, formatFloat)
 }
 
 // Runes converts the strings in the output of Parse to rune slices.
@@ -67,62 +69,19 @@ func Runes(signbit bool, integer string, fractional string, exponential int, ok
 // exponential, and will return all zero values if ok was false, see Decimal for more info.
 func Apply(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) func(n int) (signbit bool, integer []rune, fractional []rune, exponential int, ok bool) {
 	return func(n int) (bool, []rune, []rune, int, bool) {
-		if !ok {
-			return false, nil, nil, 0, false
-		}
-
-		// adjust the n decimal arg by the exponential, so we round to the actual point we want
-		// e.g. if we want to round to two decimal places, and have (false, "12", "1456", 1, true), then since the
-		// actual number is 121.456 (=12.1456 x 10 ^ 1), we want to use 3 digits from fractional, instead of 2
-		n += exponential
-
-		// shift digits between fractional and integer until n is 0
-		// NOTE: we also adjust the exponential to keep track of the actual number
-		for {
-			if n > 0 {
-				n--
-				exponential--
-				integer, fractional = moveLeft(integer, fractional)
-			} else if n < 0 {
-				n++
-				exponential++
-				integer, fractional = moveRight(integer, fractional)
-			} else {
-				break
-			}
-		}
-
-		// if fractional starts with 5 or above then add 1 to the uint that integer represents (round part 1)
-		if roundFractional(fractional) {
-			integer = incrementInteger(integer)
-		}
-
-		// discard anything left in fractional (round part 2)
-		fractional = nil
-
-		// signbit and ok are unchanged, integer, fractional and exponential may have been modified
-		return signbit, integer, fractional, exponential, true
+		return ApplyMode(signbit, integer, fractional, exponential, ok)(n, RoundHalfAwayFromZero)
 	}
 }
 
-// Join can be used with the output of Runes(Parse(...)) to build a sane decimal string, it returns false if parse did.
+// Join can be used with the output of Runes(Parse(...)) to build a sane decimal string, it returns false if parse did,
+// or if the length of the output would exceed the max int, see also Limits.Join.
 func Join(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (string, bool) {
-	if !ok {
+	if !ok || joinLength(signbit, integer, fractional, exponential) == maxInt {
 		return "", false
 	}
 
 	// bring exponential to 0 by moving digits between integer and fractional
-	for {
-		if exponential > 0 {
-			exponential--
-			integer, fractional = moveLeft(integer, fractional)
-		} else if exponential < 0 {
-			exponential++
-			integer, fractional = moveRight(integer, fractional)
-		} else {
-			break
-		}
-	}
+	integer, fractional = shift(integer, fractional, exponential)
 
 	// trim any leading zeros from integer
 	for len(integer) > 0 {
@@ -147,7 +106,7 @@ func Join(signbit bool, integer []rune, fractional []rune, exponential int, ok b
 
 	// ensure integer has at least one digit ('0' if none)
 	if len(integer) == 0 {
-		integer = append(integer, '0')
+		integer = []rune{'0'}
 	}
 
 	// build the output (init with capacity of all digits + 2, to account for potential sign and decimal)
@@ -221,74 +180,12 @@ func Parse(v interface{}) (signbit bool, integer string, fractional string, expo
 	return ParseString(String(v))
 }
 
-// ParseString is the implementation of Parse after string conversion has been applied.
+// ParseString is the implementation of Parse after string conversion has been applied, see ParseStringErr for a
+// variant which describes why parsing failed.
 func ParseString(s string) (signbit bool, integer string, fractional string, exponential int, ok bool) {
-	// strip whitespace and commas
-	s = strings.Map(
-		func(r rune) rune {
-			if unicode.IsSpace(r) || r == ',' {
-				return -1
-			}
-			return r
-		},
-		s,
-	)
-
-	// use a regex to split out the initial components
-	sm := parseRegex.FindStringSubmatch(s)
-
-	smLen := len(sm)
-	if smLen == 0 {
-		// no match, we can just return (false, "", "", 0, false)
-		return
-	}
-
-	// parsing success, any failures below must return directly or set ok back to false
-	ok = true
-
-	if smLen > 1 && sm[1] == `-` {
-		// there was a negative sign present, set the flag
-		// NOTE: we may have to clear it again if the rest of the expression evaluates to zero
-		signbit = true
-	}
-
-	if smLen > 2 {
-		// parsed an integer component, trim all leading zeros
-		integer = strings.TrimLeftFunc(
-			sm[2],
-			func(r rune) bool {
-				return r == '0'
-			},
-		)
-	}
-
-	if smLen > 3 {
-		// parsed a fractional component, trim all trailing zeros
-		fractional = strings.TrimRightFunc(
-			sm[3],
-			func(r rune) bool {
-				return r == '0'
-			},
-		)
-	}
-
-	if signbit && integer == "" && fractional == "" {
-		// we parsed a negative sign, but we then parsed an expression that evaluates to 0, remove the negative
-		signbit = false
-	}
-
-	if smLen > 4 && sm[4] != "" {
-		// parsed an exponential component, convert it to an integer, note it must be well-formed, and must fit
-		if v, err := strconv.Atoi(sm[4]); err != nil {
-			// bail out, directly return all zero values
-			return false, "", "", 0, false
-		} else {
-			// update the exponential to return with the parsed int
-			exponential = v
-		}
-	}
-
-	// we are done!
+	var err error
+	signbit, integer, fractional, exponential, err = ParseStringErr(s)
+	ok = err == nil
 	return
 }
 
@@ -315,9 +212,12 @@ var ensureExponentFloat64 = EnsureExponent(MinExponentFloat64, MaxExponentFloat6
 // the output to the format [-]INTEGER_COMPONENT[.FRACTIONAL_COMPONENT], with unnecessary trailing or leading
 // zeros stripped, and the sign only present for negatives that don't evaluate as -0.
 //
-// NOTE: the implementation is effectively Join(Apply(Runes(ParseString(String(v))))(n))
+// The infinities and NaN values (e.g. math.Inf(1), or Inf(1), but not strings like "NaN") are returned as
+// formatted by String, i.e. +Inf, -Inf, and NaN, without rounding.
+//
+// NOTE: the implementation for all other values is effectively Join(Apply(Runes(ParseString(String(v))))(n))
 func Decimal(v interface{}, n int) (string, bool) {
-	return DecimalString(String(v), n)
+	return decimalMode(v, String, n, RoundHalfAwayFromZero)
 }
 
 // DecimalString is the Decimal implementation after converting the value to a string using String.
@@ -327,26 +227,59 @@ func DecimalString(s string, n int) (string, bool) {
 
 // moveLeft moves the first digit of fractional (default to 0) to the end of integer
 func moveLeft(integer, fractional []rune) ([]rune, []rune) {
-	digit := '0'
-	if len(fractional) != 0 {
-		digit = fractional[0]
-		fractional = fractional[1:]
-	}
-	integer = append(integer, digit)
-	return integer, fractional
+	return shiftLeft(integer, fractional, 1)
 }
 
 // moveRight moves the last digit of integer (default to 0) to the start of fractional
 func moveRight(integer, fractional []rune) ([]rune, []rune) {
-	digit := '0'
-	if l := len(integer); l != 0 {
-		digit = integer[l-1]
-		integer = integer[:l-1]
+	return shiftRight(integer, fractional, 1)
+}
+
+// shift moves n digits from fractional to integer, if n is positive, or -n digits from integer to fractional, if n
+// is negative, i.e. it multiplies integer.fractional by 10 ^ n, in O(digits) time.
+func shift(integer, fractional []rune, n int) ([]rune, []rune) {
+	if n > 0 {
+		return shiftLeft(integer, fractional, n)
+	}
+	if n < 0 {
+		return shiftRight(integer, fractional, -n)
 	}
-	fractional = append(append(make([]rune, 0, len(fractional)+1), digit), fractional...)
 	return integer, fractional
 }
 
+// shiftLeft moves the first n digits of fractional (default to 0) to the end of integer
+func shiftLeft(integer, fractional []rune, n int) ([]rune, []rune) {
+	m := n
+	if m > len(fractional) {
+		m = len(fractional)
+	}
+	// NOTE: a new slice is used for the result, as appending to integer may write to the caller's backing array
+	result := make([]rune, 0, len(integer)+n)
+	result = append(result, integer...)
+	result = append(result, fractional[:m]...)
+	for i := m; i < n; i++ {
+		result = append(result, '0')
+	}
+	return result, fractional[m:]
+}
+
+// shiftRight moves the last n digits of integer (default to 0) to the start of fractional
+func shiftRight(integer, fractional []rune, n int) ([]rune, []rune) {
+	m := n
+	if m > len(integer) {
+		m = len(integer)
+	}
+	result := make([]rune, 0, n+len(fractional))
+	for i := m; i < n; i++ {
+		result = append(result, '0')
+	}
+	result = append(result, integer[len(integer)-m:]...)
+	result = append(result, fractional...)
+	// the capacity of integer is limited, so appending to it will not write over the digits that were moved
+	l := len(integer) - m
+	return integer[:l:l], result
+}
+
 // incrementInteger increments an integer expressed as a slice of runes (digits) by 1
 func incrementInteger(integer []rune) []rune {
 	done := false
@@ -373,7 +306,3 @@ func roundFractional(fractional []rune) bool {
 	}
 	return fractional[0] >= '5'
 }
-
-var (
-	parseRegex = regexp.MustCompile(`(?i)^((?:)|(?:\+)|(?:-))(\d+)(?:(?:)|(?:\.(\d+)))(?:(?:)|(?:(?:(?:x10\^)|(?:\*10\^)|(?:e))((?:(?:)|(?:\+)|(?:-))\d+)))$`)
-)
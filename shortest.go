@@ -0,0 +1,52 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"strconv"
+)
+
+// StringShortest is like String, but formats float32 and float64 values using the shortest representation that
+// will parse back to the same value (strconv 'g' format, with a precision of -1), rather than FormatFloat32 or
+// FormatFloat64, e.g. 0.1 instead of 0.10000000000000001.
+//
+// This changes the results of rounding, as the shortest representation is the decimal the value was most likely
+// written as, rather than an approximation of the binary value, e.g. DecimalShortest(2.675, 2) is "2.68", but
+// Decimal(2.675, 2) is "2.67", as the nearest float64 to 2.675 is slightly less than it, see also StringExact.
+func StringShortest(v interface{}) string {
+	return formatValue(v, formatFloatShortest)
+}
+
+// ParseShortest is like Parse, but uses StringShortest.
+func ParseShortest(v interface{}) (signbit bool, integer string, fractional string, exponential int, ok bool) {
+	return ParseString(StringShortest(v))
+}
+
+// DecimalShortest is like Decimal, but uses StringShortest.
+func DecimalShortest(v interface{}, n int) (string, bool) {
+	return decimalMode(v, StringShortest, n, RoundHalfAwayFromZero)
+}
+
+// DecimalShortestMode is like DecimalMode, but uses StringShortest.
+func DecimalShortestMode(v interface{}, n int, mode RoundingMode) (string, bool) {
+	return decimalMode(v, StringShortest, n, mode)
+}
+
+// formatFloatShortest is the float formatter for StringShortest.
+func formatFloatShortest(f float64, bitSize int) string {
+	return strconv.FormatFloat(f, 'g', -1, bitSize)
+}
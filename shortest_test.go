@@ -0,0 +1,91 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func ExampleStringShortest() {
+	a, b := 0.1, 0.2
+	fmt.Println(String(a), StringShortest(a))
+	fmt.Println(String(a+b), StringShortest(a+b))
+	fmt.Println(String(float32(0.1)), StringShortest(float32(0.1)))
+	fmt.Println(String(1e23), StringShortest(1e23))
+	fmt.Println(String(42), StringShortest(42))
+
+	// Output:
+	// 0.10000000000000001 0.1
+	// 0.30000000000000004 0.30000000000000004
+	// 0.100000001 0.1
+	// 9.9999999999999992e+22 1e+23
+	// 42 42
+}
+
+// The classic double rounding cases, where the float64 nearest to the literal is slightly below the half-way
+// point, so the fixed precision of String exposes that, but StringShortest recovers the literal.
+func ExampleDecimalShortest() {
+	for _, tc := range []struct {
+		V float64
+		N int
+	}{
+		{1.005, 2},
+		{2.675, 2},
+		{0.15, 1},
+		{0.1, 17},
+		{1e23, 0},
+	} {
+		a, _ := Decimal(tc.V, tc.N)
+		b, _ := DecimalShortest(tc.V, tc.N)
+		fmt.Println(a, b)
+	}
+
+	// Output:
+	// 1 1.01
+	// 2.67 2.68
+	// 0.1 0.2
+	// 0.10000000000000001 0.1
+	// 99999999999999992000000 100000000000000000000000
+}
+
+func TestStringShortest_roundTrip(t *testing.T) {
+	for _, f := range []float64{0, 1, -1, 0.1, 1.0 / 3, math.Pi, math.MaxFloat64, math.SmallestNonzeroFloat64, -2.5e-300, 123456789.123} {
+		s := StringShortest(f)
+		x, _ := ParseNumber(s)
+		if v, err := x.Float64(); err != nil || v != f {
+			t.Error(f, s, v, err)
+		}
+	}
+	for _, f := range []float32{0, 1, -1, 0.1, 1.0 / 3, math.Pi, math.MaxFloat32, math.SmallestNonzeroFloat32} {
+		s := StringShortest(f)
+		if v, err := strconv.ParseFloat(s, 32); err != nil || float32(v) != f {
+			t.Error(f, s, v, err)
+		}
+		if v, err := Float32(Runes(ParseShortest(f))); err != nil || v != f {
+			t.Error(f, s, v, err)
+		}
+	}
+	if s, ok := DecimalShortestMode(2.675, 2, RoundDown); s != "2.67" || !ok {
+		t.Error(s, ok)
+	}
+	if s, ok := DecimalShortestMode(2.665, 2, RoundHalfEven); s != "2.66" || !ok {
+		t.Error(s, ok)
+	}
+}
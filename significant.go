@@ -0,0 +1,130 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+// ApplySignificant is like Apply, but rounds to k significant digits, counting from the leading non-zero digit,
+// rather than to n decimal places, e.g. 0.000123456 rounds to 0.000123 and 123456 rounds to 123000, for k=3.
+//
+// NOTE: zero is always left as zero, rounding may carry into an extra digit (9.99 rounds to 10.0, for k=2), and
+// ok will be false if k is less than 1, or if the leading non-zero digit is outside the range of int exponents.
+func ApplySignificant(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) func(k int) (signbit bool, integer []rune, fractional []rune, exponential int, ok bool) {
+	return func(k int) (bool, []rune, []rune, int, bool) {
+		return ApplySignificantMode(signbit, integer, fractional, exponential, ok)(k, RoundHalfAwayFromZero)
+	}
+}
+
+// ApplySignificantMode is like ApplySignificant but supports rounding modes other than RoundHalfAwayFromZero, see
+// also ApplyMode.
+func ApplySignificantMode(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) func(k int, mode RoundingMode) (signbit bool, integer []rune, fractional []rune, exponential int, ok bool) {
+	return func(k int, mode RoundingMode) (bool, []rune, []rune, int, bool) {
+		if k < 1 {
+			return false, nil, nil, 0, false
+		}
+		p, nonZero, inRange := leadingExponent(integer, fractional, exponential)
+		if !nonZero {
+			// zero has no significant digits, so we just (validate and) return it, without shifting anything
+			return ApplyMode(signbit, integer, fractional, exponential, ok)(-exponential, mode)
+		}
+		if !inRange {
+			return false, nil, nil, 0, false
+		}
+		// keeping k digits, starting from the one at 10 ^ p, is the same as rounding to k-1-p decimal places
+		if p >= 0 || k-1 <= maxInt+p {
+			return ApplyMode(signbit, integer, fractional, exponential, ok)(k-1-p, mode)
+		}
+		// k-1-p would overflow, so we round with the leading digit moved to 10 ^ 0, then shift the digits of the
+		// result such that it can be moved back, by using p as the exponential
+		signbit, integer, fractional, e, ok := ApplyMode(signbit, integer, fractional, exponential-p, ok)(k-1, mode)
+		if !ok {
+			return false, nil, nil, 0, false
+		}
+		integer, fractional = shift(integer, fractional, e)
+		return signbit, integer, fractional, p, true
+	}
+}
+
+// Significant rounds a value to k significant digits, like Decimal, but using ApplySignificant, note that the
+// infinities and NaN are returned in the same way as Decimal, or false if k is less than 1.
+func Significant(v interface{}, k int) (string, bool) {
+	return SignificantMode(v, k, RoundHalfAwayFromZero)
+}
+
+// SignificantString is the Significant implementation after converting the value to a string using String.
+func SignificantString(s string, k int) (string, bool) {
+	return Join(ApplySignificant(Runes(ParseString(s)))(k))
+}
+
+// SignificantMode is like Significant but supports rounding modes other than RoundHalfAwayFromZero.
+func SignificantMode(v interface{}, k int, mode RoundingMode) (string, bool) {
+	if x, ok := nonFinite(v); ok {
+		if _, ok := x.RoundSignificantMode(k, mode); !ok {
+			return "", false
+		}
+		return x.String(), true
+	}
+	return SignificantStringMode(String(v), k, mode)
+}
+
+// SignificantStringMode is the SignificantMode implementation after converting the value to a string using String.
+func SignificantStringMode(s string, k int, mode RoundingMode) (string, bool) {
+	return Join(ApplySignificantMode(Runes(ParseString(s)))(k, mode))
+}
+
+// RoundSignificant returns the number rounded to k significant digits, see ApplySignificant, note that it returns
+// zero if k is less than 1.
+func (x Number) RoundSignificant(k int) Number {
+	if x.isSpecial() {
+		return x
+	}
+	r, _ := NewNumberRunes(ApplySignificant(x.Runes())(k))
+	return r
+}
+
+// RoundSignificantMode returns the number rounded to k significant digits using the given mode, or false if the
+// mode is not valid, or k is less than 1, see ApplySignificantMode.
+func (x Number) RoundSignificantMode(k int, mode RoundingMode) (Number, bool) {
+	if x.isSpecial() {
+		if !mode.valid() || k < 1 {
+			return Number{}, false
+		}
+		return x, true
+	}
+	return NewNumberRunes(ApplySignificantMode(x.Runes())(k, mode))
+}
+
+// leadingExponent returns the exponent p such that the leading non-zero digit of integer.fractional x 10 ^
+// exponential is in the 10 ^ p position, or nonZero=false if there are no non-zero digits, where p is clamped to
+// the range of int, with inRange=false, if it would overflow.
+func leadingExponent(integer []rune, fractional []rune, exponential int) (p int, nonZero bool, inRange bool) {
+	for i, r := range integer {
+		if r != '0' {
+			return exponentOffset(exponential, len(integer)-1-i)
+		}
+	}
+	for i, r := range fractional {
+		if r != '0' {
+			return exponentOffset(exponential, -(i + 1))
+		}
+	}
+	return 0, false, true
+}
+
+// exponentOffset implements leadingExponent, returning exponential+offset, clamped to the range of int.
+func exponentOffset(exponential int, offset int) (int, bool, bool) {
+	p := addSaturating(exponential, offset)
+	return p, true, p-offset == exponential
+}
@@ -0,0 +1,160 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleSignificant() {
+	fmt.Println(Significant("0.000123456", 3))
+	fmt.Println(Significant(123456, 3))
+	fmt.Println(Significant(-123456, 3))
+	fmt.Println(Significant("9.99", 2))
+	fmt.Println(Significant("1.23456e-30", 4))
+	fmt.Println(Significant("0012.5e3", 2))
+	fmt.Println(Significant("0.000", 3))
+	fmt.Println(Significant("abc", 3))
+
+	// Output:
+	// 0.000123 true
+	// 123000 true
+	// -123000 true
+	// 10 true
+	// 0.000000000000000000000000000001235 true
+	// 13000 true
+	// 0 true
+	//  false
+}
+
+func ExampleSignificantMode() {
+	fmt.Println(SignificantMode("0.0001225", 3, RoundHalfEven))
+	fmt.Println(SignificantMode("0.0001235", 3, RoundHalfEven))
+	fmt.Println(SignificantMode(-1201, 2, RoundCeiling))
+	fmt.Println(SignificantMode(-1201, 2, RoundFloor))
+	fmt.Println(SignificantMode(1201, 2, RoundingMode(-1)))
+
+	// Output:
+	// 0.000122 true
+	// 0.000124 true
+	// -1200 true
+	// -1300 true
+	//  false
+}
+
+func TestNumber_RoundSignificant(t *testing.T) {
+	x, _ := ParseNumber("98765.4321e-2")
+	if r := x.RoundSignificant(2); r.String() != "990" {
+		t.Error(r)
+	}
+	if r, ok := x.RoundSignificantMode(6, RoundDown); !ok || r.String() != "987.654" {
+		t.Error(r, ok)
+	}
+	if r, ok := Inf(1).RoundSignificantMode(0, RoundDown); ok || r != (Number{}) {
+		t.Error(r, ok)
+	}
+}
+
+func TestSignificant_invalid(t *testing.T) {
+	for _, s := range []string{"987", "123", "0", "0.000123"} {
+		for _, k := range []int{0, -1, -5} {
+			if v, ok := SignificantString(s, k); ok || v != "" {
+				t.Error(s, k, v, ok)
+			}
+			if v, ok := SignificantStringMode(s, k, RoundHalfEven); ok || v != "" {
+				t.Error(s, k, v, ok)
+			}
+		}
+	}
+	x, _ := ParseNumber("987")
+	if r, ok := x.RoundSignificantMode(0, RoundHalfEven); ok || r != (Number{}) {
+		t.Error(r, ok)
+	}
+}
+
+func TestApplySignificant_exponentRange(t *testing.T) {
+	for _, tc := range []struct {
+		Input  string
+		K      int
+		Output string
+	}{
+		{"1e-9223372036854775808", 3, "1e-9223372036854775808"},
+		{"1.2345e-9223372036854775807", 3, "1.23e-9223372036854775807"},
+		{"1.2345e-9223372036854775808", 3, "1.23e-9223372036854775808"},
+		{"9.99e-9223372036854775800", 2, "1e-9223372036854775799"},
+		{"9.99e-9223372036854775808", 2, "1e-9223372036854775807"},
+		{"0.5e-9223372036854775808", 3, ""},
+		{"1e9223372036854775807", 1, "1e9223372036854775807"},
+		{"1.25e9223372036854775807", 2, "1.3e9223372036854775807"},
+		{"12e9223372036854775807", 1, ""},
+		{"1e-5", maxInt, "0.00001"},
+		{"1e5", maxInt, "100000"},
+		{"0e-9223372036854775808", 3, "0"},
+	} {
+		x, ok := NewNumberRunes(ApplySignificant(Runes(ParseString(tc.Input)))(tc.K))
+		expected, _ := ParseNumber(tc.Output)
+		if ok != (tc.Output != "") || (ok && x.Cmp(expected) != 0) {
+			t.Error(tc.Input, tc.K, ok, x.integer, x.fractional, x.exponential)
+		}
+	}
+}
+
+func TestApplySignificantMode_invalidMode(t *testing.T) {
+	for _, s := range []string{"1.5", "1.5e-9223372036854775808"} {
+		if signbit, integer, fractional, exponential, ok := ApplySignificantMode(Runes(ParseString(s)))(1, RoundingMode(-1)); signbit || integer != nil || fractional != nil || exponential != 0 || ok {
+			t.Error(s, signbit, integer, fractional, exponential, ok)
+		}
+	}
+}
+
+func TestLeadingExponent(t *testing.T) {
+	type TestCase struct {
+		Integer, Fractional string
+		Exponential         int
+		P                   int
+		NonZero             bool
+		OutOfRange          bool
+	}
+
+	testCases := []TestCase{
+		{},
+		{Integer: "000", Fractional: "000", Exponential: 5},
+		{Integer: "1", P: 0, NonZero: true},
+		{Integer: "0012", P: 1, NonZero: true},
+		{Integer: "12", Exponential: -5, P: -4, NonZero: true},
+		{Fractional: "001", P: -3, NonZero: true},
+		{Integer: "0", Fractional: "5", Exponential: 3, P: 2, NonZero: true},
+		{Integer: "1", Exponential: maxInt, P: maxInt, NonZero: true},
+		{Integer: "12", Exponential: maxInt, P: maxInt, NonZero: true, OutOfRange: true},
+		{Integer: "12", Exponential: maxInt - 1, P: maxInt, NonZero: true},
+		{Integer: "1", Exponential: minInt, P: minInt, NonZero: true},
+		{Fractional: "1", Exponential: minInt, P: minInt, NonZero: true, OutOfRange: true},
+		{Fractional: "1", Exponential: minInt + 1, P: minInt, NonZero: true},
+		{Fractional: "0", Exponential: minInt},
+	}
+
+	for i, testCase := range testCases {
+		name := fmt.Sprintf("TestLeadingExponent_#%d", i+1)
+
+		p, nonZero, inRange := leadingExponent([]rune(testCase.Integer), []rune(testCase.Fractional), testCase.Exponential)
+
+		if p != testCase.P || nonZero != testCase.NonZero || inRange == testCase.OutOfRange {
+			t.Error(name, "output", p, nonZero, inRange, "!= expected", testCase.P, testCase.NonZero, !testCase.OutOfRange)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Class classifies a Number as a finite value, or one of the special values, which can only be produced by a
+// Parser with Specials enabled, by Inf or NaN, or by arithmetic involving them.
+//
+// The special values are carried through the Number methods with the following semantics:
+// - rounding returns them unchanged
+// - formatting uses the same strings as String(float64), i.e. +Inf, -Inf, NaN, and -0
+// - Float32 and Float64 return the equivalent float values
+// - arithmetic follows IEEE 754, e.g. Inf-Inf and 0*Inf are NaN, any operation involving NaN is NaN, except that
+//   zero results are always positive zero (including -0+-0, -0*1, and -1/Inf), and Quo still returns
+//   ErrDivisionByZero for finite/0
+// - Cmp orders NaN before -Inf, and treats -0 and 0 as equal
+// - Parts and Runes return ok=false for infinities and NaN, and -0 is treated as zero by the tuple functions
+type Class int
+
+const (
+	// ClassFinite is any finite number, other than negative zero.
+	ClassFinite Class = iota
+
+	// ClassNegativeZero is negative zero.
+	ClassNegativeZero
+
+	// ClassPositiveInf is positive infinity.
+	ClassPositiveInf
+
+	// ClassNegativeInf is negative infinity.
+	ClassNegativeInf
+
+	// ClassNaN is not a number.
+	ClassNaN
+)
+
+// String returns the name of the class.
+func (c Class) String() string {
+	switch c {
+	case ClassFinite:
+		return "ClassFinite"
+	case ClassNegativeZero:
+		return "ClassNegativeZero"
+	case ClassPositiveInf:
+		return "ClassPositiveInf"
+	case ClassNegativeInf:
+		return "ClassNegativeInf"
+	case ClassNaN:
+		return "ClassNaN"
+	default:
+		return "Class(" + strconv.Itoa(int(c)) + ")"
+	}
+}
+
+// special is stored in a Number to represent the non-finite values.
+type special uint8
+
+const (
+	specialNone special = iota
+	specialInf
+	specialNaN
+)
+
+// Inf returns positive infinity if sign >= 0, or negative infinity if sign < 0.
+func Inf(sign int) Number {
+	return Number{signbit: sign < 0, special: specialInf}
+}
+
+// NaN returns a Number that is not a number.
+func NaN() Number {
+	return Number{special: specialNaN}
+}
+
+// Class returns the classification of x.
+func (x Number) Class() Class {
+	switch {
+	case x.special == specialNaN:
+		return ClassNaN
+	case x.special == specialInf && x.signbit:
+		return ClassNegativeInf
+	case x.special == specialInf:
+		return ClassPositiveInf
+	case x.signbit && x.IsZero():
+		return ClassNegativeZero
+	default:
+		return ClassFinite
+	}
+}
+
+// IsInf reports whether x is an infinity, according to sign, in the same way as math.IsInf.
+func (x Number) IsInf(sign int) bool {
+	return x.special == specialInf && (sign == 0 || (sign > 0) != x.signbit)
+}
+
+// IsNaN reports whether x is not a number.
+func (x Number) IsNaN() bool {
+	return x.special == specialNaN
+}
+
+// isFinite returns true if x is neither an infinity, nor NaN, note that it may be negative zero.
+func (x Number) isFinite() bool {
+	return x.special == specialNone
+}
+
+// isSpecial returns true if x is an infinity, NaN, or negative zero, i.e. if the Class is not ClassFinite.
+func (x Number) isSpecial() bool {
+	return x.special != specialNone || (x.signbit && x.IsZero())
+}
+
+// specialString formats the special values, and returns false for ClassFinite.
+func (x Number) specialString() (string, bool) {
+	switch x.Class() {
+	case ClassNegativeZero:
+		return "-0", true
+	case ClassPositiveInf:
+		return "+Inf", true
+	case ClassNegativeInf:
+		return "-Inf", true
+	case ClassNaN:
+		return "NaN", true
+	default:
+		return "", false
+	}
+}
+
+// nonFinite classifies a value in the same way as String, returning the infinities and NaN as a Number, for the
+// floating point kinds (including named types), complex64 and complex128 with a zero imaginary part, *big.Float,
+// Number, and pointers to them, or false for any other value, including the finite values.
+func nonFinite(v interface{}) (Number, bool) {
+	var x Number
+	switch value := v.(type) {
+	case complex64:
+		if imag(value) != 0 {
+			return Number{}, false
+		}
+		x = floatSpecial(float64(real(value)))
+	case complex128:
+		if imag(value) != 0 {
+			return Number{}, false
+		}
+		x = floatSpecial(real(value))
+	case *big.Float:
+		if value == nil || !value.IsInf() {
+			return Number{}, false
+		}
+		x = Inf(value.Sign())
+	case Number:
+		x = value
+	case *Number:
+		if value == nil {
+			return Number{}, false
+		}
+		x = *value
+	default:
+		if v == nil {
+			return Number{}, false
+		}
+		switch rv := reflect.ValueOf(v); rv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			x = floatSpecial(rv.Float())
+		case reflect.Ptr:
+			if rv.IsNil() {
+				return Number{}, false
+			}
+			return nonFinite(rv.Elem().Interface())
+		default:
+			return Number{}, false
+		}
+	}
+	return x, !x.isFinite()
+}
+
+// floatSpecial converts the infinities and NaN to a Number, returning the zero value for any finite f.
+func floatSpecial(f float64) Number {
+	switch {
+	case math.IsInf(f, 0):
+		return Inf(int(math.Copysign(1, f)))
+	case math.IsNaN(f):
+		return NaN()
+	default:
+		return Number{}
+	}
+}
+
+// specialFloat64 converts the special values to float64, and returns false for ClassFinite.
+func (x Number) specialFloat64() (float64, bool) {
+	switch x.Class() {
+	case ClassNegativeZero:
+		return math.Copysign(0, -1), true
+	case ClassPositiveInf:
+		return math.Inf(1), true
+	case ClassNegativeInf:
+		return math.Inf(-1), true
+	case ClassNaN:
+		return math.NaN(), true
+	default:
+		return 0, false
+	}
+}
+
+// specialWords are the (case insensitive) textual forms of the special values, accepted by Parser, when Specials
+// is enabled.
+var specialWords = map[string]special{
+	"inf":      specialInf,
+	"infinity": specialInf,
+	"∞":        specialInf,
+	"nan":      specialNaN,
+}
+
+// special attempts to scan a special value (an infinity, or NaN), with an optional sign.
+func (sc *scanner) special() (Number, bool) {
+	text := sc.text
+	signbit := false
+	if len(text) != 0 && (text[0] == '-' || text[0] == '+') {
+		signbit = text[0] == '-'
+		text = text[1:]
+	}
+	switch specialWords[strings.ToLower(text)] {
+	case specialInf:
+		return Number{signbit: signbit, special: specialInf}, true
+	case specialNaN:
+		return NaN(), true
+	default:
+		return Number{}, false
+	}
+}
@@ -0,0 +1,338 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func ExampleParser_specials() {
+	p := Parser{Specials: true}
+	for _, v := range []interface{}{
+		math.Inf(1),
+		math.Inf(-1),
+		math.NaN(),
+		math.Copysign(0, -1),
+		"Infinity",
+		"-∞",
+		"nan",
+		"-0.00e5",
+		1.5,
+	} {
+		x, err := p.ParseNumber(String(v))
+		f, _ := x.Float64()
+		fmt.Println(x, x.Class(), f, err)
+	}
+
+	// Output:
+	// +Inf ClassPositiveInf +Inf <nil>
+	// -Inf ClassNegativeInf -Inf <nil>
+	// NaN ClassNaN NaN <nil>
+	// -0 ClassNegativeZero -0 <nil>
+	// +Inf ClassPositiveInf +Inf <nil>
+	// -Inf ClassNegativeInf -Inf <nil>
+	// NaN ClassNaN NaN <nil>
+	// -0 ClassNegativeZero -0 <nil>
+	// 1.5 ClassFinite 1.5 <nil>
+}
+
+func ExampleNumber_Class() {
+	x, _ := ParseNumber("1.25")
+	for _, v := range []Number{x, Inf(1), Inf(-1), NaN()} {
+		r, _ := v.RoundMode(1, RoundHalfEven)
+		fmt.Println(v.Class(), r, v.Scientific(MarkerE), v.Neg(), v.Add(Inf(-1)), v.Mul(x))
+	}
+
+	// Output:
+	// ClassFinite 1.2 1.25e0 -1.25 -Inf 1.5625
+	// ClassPositiveInf +Inf +Inf -Inf NaN +Inf
+	// ClassNegativeInf -Inf -Inf +Inf -Inf -Inf
+	// ClassNaN NaN NaN NaN NaN NaN
+}
+
+func TestParser_specials(t *testing.T) {
+	for _, s := range []string{"inf", "+inf", "INF", "Infinity", "-infinity", "∞", "+∞", "NaN", "-nan", " inf "} {
+		x, err := Parser{Specials: true}.ParseNumber(s)
+		if err != nil || x.isFinite() {
+			t.Error(s, x, err)
+		}
+		if _, err := ParseNumber(s); !errors.Is(err, ErrSyntax) {
+			t.Error(s, err)
+		}
+		if _, _, _, _, err := (Parser{Specials: true}).ParseString(s); !errors.Is(err, ErrSyntax) {
+			t.Error(s, err)
+		}
+	}
+	for _, s := range []string{"infinit", "in", "nana", "--inf", "inf1", "1inf", ""} {
+		if x, err := (Parser{Specials: true}).ParseNumber(s); err == nil {
+			t.Error(s, x)
+		}
+	}
+	// negative zero is only preserved with specials enabled
+	if x, err := ParseNumber("-0"); err != nil || x.Class() != ClassFinite || x.String() != "0" {
+		t.Error(x, err)
+	}
+	if x, err := (Parser{Specials: true}).ParseNumber("-0.0e-3"); err != nil || x.Class() != ClassNegativeZero || x.String() != "-0" {
+		t.Error(x, err)
+	}
+}
+
+func TestNumber_specialFloat64(t *testing.T) {
+	p := Parser{Specials: true}
+	for _, f := range []float64{math.Inf(1), math.Inf(-1), math.NaN(), math.Copysign(0, -1), 0, 1.5, -2e-300} {
+		x, err := p.ParseNumber(String(f))
+		if err != nil {
+			t.Fatal(f, err)
+		}
+		g, err := x.Float64()
+		if err != nil {
+			t.Fatal(f, err)
+		}
+		if math.Float64bits(f) != math.Float64bits(g) && !(math.IsNaN(f) && math.IsNaN(g)) {
+			t.Error(f, g)
+		}
+		g32, err := x.Float32()
+		if err != nil {
+			t.Fatal(f, err)
+		}
+		if math.Signbit(float64(g32)) != math.Signbit(f) || math.IsInf(float64(g32), 0) != math.IsInf(f, 0) || math.IsNaN(float64(g32)) != math.IsNaN(f) {
+			t.Error(f, g32)
+		}
+	}
+}
+
+func TestNumber_specialTuple(t *testing.T) {
+	for _, x := range []Number{Inf(1), Inf(-1), NaN()} {
+		if _, ok := Join(x.Runes()); ok {
+			t.Error(x)
+		}
+		if _, _, _, _, ok := x.Parts(); ok {
+			t.Error(x)
+		}
+		if x.IsZero() || x.Normalize() != x || x.Round(2) != x || x.RoundSignificant(2) != x || x.Format(&LocaleEN) != x.String() {
+			t.Error(x)
+		}
+		if _, ok := x.RoundMode(2, RoundingMode(-1)); ok {
+			t.Error(x)
+		}
+	}
+	z, _ := Parser{Specials: true}.ParseNumber("-0")
+	if s, ok := Join(z.Runes()); s != "0" || !ok {
+		t.Error(s, ok)
+	}
+	if s := z.Engineering(MarkerUpperE); s != "-0E0" {
+		t.Error(s)
+	}
+	if z.Cmp(Number{}) != 0 || z.Sign() != 0 || z.Neg().Class() != ClassFinite || z.Abs().Class() != ClassFinite {
+		t.Error(z)
+	}
+}
+
+func TestNumber_specialArithmetic(t *testing.T) {
+	one, _ := ParseNumber("1")
+	zero := Number{}
+	for _, tc := range []struct {
+		Name   string
+		Result Number
+		Class  Class
+	}{
+		{"inf+1", Inf(1).Add(one), ClassPositiveInf},
+		{"1-inf", one.Sub(Inf(1)), ClassNegativeInf},
+		{"inf+inf", Inf(1).Add(Inf(1)), ClassPositiveInf},
+		{"inf-inf", Inf(1).Sub(Inf(1)), ClassNaN},
+		{"nan+1", NaN().Add(one), ClassNaN},
+		{"1+nan", one.Add(NaN()), ClassNaN},
+		{"-inf*-1", Inf(-1).Mul(one.Neg()), ClassPositiveInf},
+		{"inf*0", Inf(1).Mul(zero), ClassNaN},
+		{"0*-inf", zero.Mul(Inf(-1)), ClassNaN},
+		{"nan*inf", NaN().Mul(Inf(1)), ClassNaN},
+		{"abs(-inf)", Inf(-1).Abs(), ClassPositiveInf},
+	} {
+		if c := tc.Result.Class(); c != tc.Class {
+			t.Error(tc.Name, c)
+		}
+	}
+	for _, tc := range []struct {
+		X, Y  Number
+		Class Class
+	}{
+		{Inf(1), one, ClassPositiveInf},
+		{Inf(1), one.Neg(), ClassNegativeInf},
+		{Inf(-1), zero, ClassNegativeInf},
+		{Inf(1), Inf(1), ClassNaN},
+		{NaN(), one, ClassNaN},
+		{one, NaN(), ClassNaN},
+		{one, Inf(-1), ClassFinite},
+	} {
+		q, exact, err := tc.X.Quo(tc.Y, 2, RoundHalfEven)
+		if err != nil || !exact || q.Class() != tc.Class {
+			t.Error(tc.X, tc.Y, q, exact, err)
+		}
+		q, exact, err = tc.X.QuoSignificant(tc.Y, 2, RoundHalfEven)
+		if err != nil || !exact || q.Class() != tc.Class {
+			t.Error(tc.X, tc.Y, q, exact, err)
+		}
+	}
+	if _, _, err := one.Quo(zero, 2, RoundHalfEven); err != ErrDivisionByZero {
+		t.Error(err)
+	}
+	// NaN < -Inf < finite < +Inf
+	ordered := []Number{NaN(), Inf(-1), one.Neg(), zero, one, Inf(1)}
+	for i := range ordered {
+		for j := range ordered {
+			if c := ordered[i].Cmp(ordered[j]); c != cmpInt(i, j) {
+				t.Error(ordered[i], ordered[j], c)
+			}
+		}
+	}
+}
+
+func TestClass_String(t *testing.T) {
+	if s := Class(-1).String(); s != "Class(-1)" {
+		t.Error(s)
+	}
+}
+
+func TestNonFinite(t *testing.T) {
+	var (
+		nilN  *Number
+		nilF  *float64
+		inf   = Inf(-1)
+		value = 1.5
+	)
+	for _, tc := range []struct {
+		Value  interface{}
+		Output string
+	}{
+		{nil, ""},
+		{1, ""},
+		{"NaN", ""},
+		{1.5, ""},
+		{&value, ""},
+		{nilF, ""},
+		{math.Inf(1), "+Inf"},
+		{&[]float64{math.NaN()}[0], "NaN"},
+		{float32(math.Inf(-1)), "-Inf"},
+		{specialFloat(math.Inf(1)), "+Inf"},
+		{complex64(complex(math.Inf(1), 0)), "+Inf"},
+		{complex64(complex(math.Inf(1), 1)), ""},
+		{complex(math.NaN(), 0), "NaN"},
+		{complex(math.NaN(), 1), ""},
+		{complex(1, 0), ""},
+		{(*big.Float)(nil), ""},
+		{big.NewFloat(1), ""},
+		{new(big.Float).SetInf(true), "-Inf"},
+		{inf, "-Inf"},
+		{&inf, "-Inf"},
+		{nilN, ""},
+		{Number{}, ""},
+		{Number{signbit: true}, ""},
+	} {
+		x, ok := nonFinite(tc.Value)
+		if ok != (tc.Output != "") || (ok && x.String() != tc.Output) {
+			t.Errorf("%#v: %v %v", tc.Value, x, ok)
+		}
+	}
+}
+
+func TestNumber_Scientific_specials(t *testing.T) {
+	for _, tc := range []struct {
+		Value  Number
+		Output string
+	}{
+		{Inf(1), "+Inf"},
+		{Inf(-1), "-Inf"},
+		{NaN(), "NaN"},
+		{Number{signbit: true}, "-0e0"},
+	} {
+		if s := tc.Value.Scientific(MarkerE); s != tc.Output {
+			t.Error(tc.Value, s)
+		}
+		if s := tc.Value.Engineering(MarkerE); s != tc.Output {
+			t.Error(tc.Value, s)
+		}
+	}
+}
+
+func TestDecimal_specials(t *testing.T) {
+	for _, tc := range []struct {
+		Value  interface{}
+		Output string
+	}{
+		{math.Inf(1), "+Inf"},
+		{math.Inf(-1), "-Inf"},
+		{math.NaN(), "NaN"},
+		{float32(math.Inf(1)), "+Inf"},
+		{Inf(-1), "-Inf"},
+		{NaN(), "NaN"},
+		{math.Copysign(0, -1), "0"},
+		{complex(math.Inf(-1), 0), "-Inf"},
+		{new(big.Float).SetInf(false), "+Inf"},
+		{specialFloat(math.NaN()), "NaN"},
+		{&[]float64{math.Inf(-1)}[0], "-Inf"},
+	} {
+		if v, ok := Decimal(tc.Value, 2); !ok || v != tc.Output {
+			t.Error(tc.Value, v, ok)
+		}
+		if v, ok := DecimalMode(tc.Value, 2, RoundHalfEven); !ok || v != tc.Output {
+			t.Error(tc.Value, v, ok)
+		}
+		if v, ok := DecimalExact(tc.Value, 2); !ok || v != tc.Output {
+			t.Error(tc.Value, v, ok)
+		}
+		if v, ok := DecimalShortestMode(tc.Value, 2, RoundDown); !ok || v != tc.Output {
+			t.Error(tc.Value, v, ok)
+		}
+		if v, ok := Significant(tc.Value, 2); !ok || v != tc.Output {
+			t.Error(tc.Value, v, ok)
+		}
+		if v, ok := SignificantMode(tc.Value, 2, RoundUp); !ok || v != tc.Output {
+			t.Error(tc.Value, v, ok)
+		}
+		if v, ok := FormatUnit(tc.Value, UnitPercent, 2, RoundHalfEven); !ok || v != tc.Output+"%" {
+			t.Error(tc.Value, v, ok)
+		}
+	}
+	if v, ok := Significant(math.Inf(1), 0); ok || v != "" {
+		t.Error(v, ok)
+	}
+	if v, ok := FormatUnit(math.NaN(), UnitPercent, 2, RoundingMode(-1)); ok || v != "" {
+		t.Error(v, ok)
+	}
+	if v, ok := DecimalMode(math.Inf(1), 2, RoundingMode(-1)); ok || v != "" {
+		t.Error(v, ok)
+	}
+	// strings are never classified as special values, consistent with the string variants
+	for _, v := range []string{"+Inf", "NaN", "inf"} {
+		if s, ok := DecimalString(v, 2); ok || s != "" {
+			t.Error(v, s, ok)
+		}
+		if s, ok := Decimal(v, 2); ok || s != "" {
+			t.Error(v, s, ok)
+		}
+		if s, ok := Significant(v, 2); ok || s != "" {
+			t.Error(v, s, ok)
+		}
+	}
+}
+
+// specialFloat is a named float type, which is classified via reflection.
+type specialFloat float64
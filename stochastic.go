@@ -0,0 +1,78 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// ApplyStochastic is like Apply, but uses stochastic rounding, where the probability of rounding away from zero is
+// equal to the discarded fraction (of the last retained digit), e.g. 1.25 will round to 1.3 with a probability of
+// 0.5 (n=1), and 1.21 will round to 1.3 with a probability of 0.1, so the expected value of the result is the
+// input value, which makes it unbiased.
+//
+// All the discarded digits are used, by comparing them with uniformly distributed random digits, drawn from src,
+// one at a time, until they differ, which means the number of random values used is usually 1, and is 0 if the
+// value is exact, where src is used via rand.New, and calls to the returned func must not be made concurrently
+// with other uses of src. A nil src is not valid, and will cause the returned func to return false.
+func ApplyStochastic(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) func(n int, src rand.Source) (signbit bool, integer []rune, fractional []rune, exponential int, ok bool) {
+	return func(n int, src rand.Source) (bool, []rune, []rune, int, bool) {
+		if !ok || src == nil {
+			return false, nil, nil, 0, false
+		}
+		integer, exponential := applyFunc(integer, fractional, exponential, n, func(integer []rune, fractional []rune, zeros int) bool {
+			return roundStochastic(rand.New(src), fractional, zeros)
+		})
+		return signbit, integer, nil, exponential, true
+	}
+}
+
+// RoundStochastic returns the number rounded to n decimal places using stochastic rounding, or false if src is nil,
+// see ApplyStochastic, note that the special values are returned unchanged.
+func (x Number) RoundStochastic(n int, src rand.Source) (Number, bool) {
+	if src == nil {
+		return Number{}, false
+	}
+	if x.isSpecial() {
+		return x, true
+	}
+	return NewNumberRunes(ApplyStochastic(x.Runes())(n, src))
+}
+
+// roundStochastic returns true with a probability equal to the fraction 0.ZZZDDD..., where Z is zeros repeated
+// the given number of times, and D are the digits, by lazily comparing them with random digits.
+func roundStochastic(rng *rand.Rand, digits []rune, zeros int) bool {
+	if strings.Trim(string(digits), "0") == "" {
+		// exact, avoid consuming any random values
+		return false
+	}
+	for i := 0; ; i++ {
+		d := '0'
+		if i >= zeros {
+			if i-zeros >= len(digits) {
+				// the random digits so far are equal to all the digits, so the random fraction must be >= the
+				// discarded fraction
+				return false
+			}
+			d = digits[i-zeros]
+		}
+		if r := '0' + rune(rng.Intn(10)); r != d {
+			return r < d
+		}
+	}
+}
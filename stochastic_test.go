@@ -0,0 +1,153 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func ExampleApplyStochastic() {
+	src := rand.NewSource(1)
+	counts := make(map[string]int)
+	f := ApplyStochastic(Runes(Parse(-1.23)))
+	for i := 0; i < 1000; i++ {
+		s, _ := Join(f(1, src))
+		counts[s]++
+	}
+	fmt.Println(counts["-1.2"] > 600, counts["-1.3"] > 200, counts["-1.2"]+counts["-1.3"])
+
+	// Output:
+	// true true 1000
+}
+
+// countingSource counts the number of values used.
+type countingSource struct {
+	rand.Source
+	count int
+}
+
+func (s *countingSource) Int63() int64 {
+	s.count++
+	return s.Source.Int63()
+}
+
+func TestApplyStochastic_distribution(t *testing.T) {
+	src := rand.NewSource(7)
+	for _, tc := range []struct {
+		Input string
+		N     int
+		Up    string
+		P     float64
+	}{
+		{"1.25", 1, "1.3", 0.5},
+		{"1.21", 1, "1.3", 0.1},
+		{"1.299", 1, "1.3", 0.99},
+		{"-0.875", 0, "-1", 0.875},
+		{"0.000123", 3, "0.001", 0.123},
+		{"1234", -2, "1300", 0.34},
+		{"5e-3", -1, "10", 0.0005},
+		{"0.5", 1, "0.6", 0},
+	} {
+		const trials = 20000
+		up := 0
+		f := ApplyStochastic(Runes(ParseString(tc.Input)))
+		for i := 0; i < trials; i++ {
+			s, ok := Join(f(tc.N, src))
+			if !ok {
+				t.Fatal(tc.Input)
+			}
+			if s == tc.Up {
+				up++
+			} else if d, _ := DecimalStringMode(tc.Input, tc.N, RoundDown); s != d {
+				t.Fatal(tc.Input, s)
+			}
+		}
+		// 5 standard deviations
+		p := float64(up) / trials
+		if tolerance := 5 * math.Sqrt(tc.P*(1-tc.P)/trials); math.Abs(p-tc.P) > tolerance+1e-9 {
+			t.Error(tc.Input, tc.N, p, tc.P)
+		}
+	}
+}
+
+func TestApplyStochastic_exact(t *testing.T) {
+	src := &countingSource{Source: rand.NewSource(1)}
+	for _, s := range []string{"0", "1.25", "1200", "1e-5", "-7.5e3"} {
+		for _, n := range []int{-4, -2, 0, 2, 5} {
+			d, _ := DecimalStringMode(s, n, RoundDown)
+			if u, _ := DecimalStringMode(s, n, RoundUp); d == u {
+				r, _ := Join(ApplyStochastic(Runes(ParseString(s)))(n, src))
+				if r != d {
+					t.Error(s, n, r, d)
+				}
+			}
+		}
+	}
+	if src.count != 0 {
+		t.Error(src.count)
+	}
+	if _, _, _, _, ok := ApplyStochastic(Runes(ParseString("invalid")))(2, src); ok {
+		t.Error(ok)
+	}
+	if _, _, _, _, ok := ApplyStochastic(Runes(ParseString("1.25")))(1, nil); ok {
+		t.Error(ok)
+	}
+}
+
+func TestApplyStochastic_reuse(t *testing.T) {
+	src := rand.NewSource(3)
+	f := ApplyStochastic(Runes(ParseString("1.95")))
+	for i := 0; i < 100; i++ {
+		if s, ok := Join(f(1, src)); (s != "1.9" && s != "2") || !ok {
+			t.Fatal(i, s, ok)
+		}
+	}
+}
+
+func TestNumber_RoundStochastic_nilSource(t *testing.T) {
+	x, _ := ParseNumber("1.25")
+	for _, x := range []Number{x, Inf(1), NaN()} {
+		if r, ok := x.RoundStochastic(1, nil); ok || r != (Number{}) {
+			t.Error(x, r, ok)
+		}
+	}
+}
+
+func TestApplyStochastic_deterministic(t *testing.T) {
+	x, _ := ParseNumber("12.3456")
+	var a, b []Number
+	for i, src := 0, rand.NewSource(42); i < 50; i++ {
+		r, ok := x.RoundStochastic(2, src)
+		if !ok {
+			t.Fatal(i)
+		}
+		a = append(a, r)
+	}
+	for i, src := 0, rand.NewSource(42); i < 50; i++ {
+		r, _ := x.RoundStochastic(2, src)
+		b = append(b, r)
+	}
+	if fmt.Sprint(a) != fmt.Sprint(b) {
+		t.Error(a, b)
+	}
+	if r, ok := Inf(1).RoundStochastic(2, rand.NewSource(1)); !ok || !r.IsInf(1) {
+		t.Error(r, ok)
+	}
+}
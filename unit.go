@@ -0,0 +1,107 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"strings"
+)
+
+// Unit is a suffix that scales a number by a power of 10, e.g. a percentage, where "12.5%" is 12.5 x 10 ^ -2, which
+// is applied by adjusting the exponential, so it's always exact.
+type Unit struct {
+	// Suffix is the text following the number, matched case insensitively when parsing.
+	Suffix string
+
+	// Exponent is the power of 10 the number is multiplied by, e.g. -2 for a percentage.
+	Exponent int
+}
+
+var (
+	// UnitPercent is a percentage, e.g. 12.5%.
+	UnitPercent = Unit{Suffix: "%", Exponent: -2}
+
+	// UnitPerMille is per-mille, e.g. 3‰.
+	UnitPerMille = Unit{Suffix: "‰", Exponent: -3}
+
+	// UnitPerTenThousand is per ten thousand, or permyriad, e.g. 25‱.
+	UnitPerTenThousand = Unit{Suffix: "‱", Exponent: -4}
+
+	// UnitBasisPoint is basis points, e.g. 25bp, which is the same as UnitPerTenThousand.
+	UnitBasisPoint = Unit{Suffix: "bp", Exponent: -4}
+
+	// UnitBasisPoints is an alternate suffix for UnitBasisPoint, e.g. 25bps.
+	UnitBasisPoints = Unit{Suffix: "bps", Exponent: -4}
+
+	// PercentUnits contains all the percent-like units, for use as Parser.Units.
+	PercentUnits = []Unit{UnitPercent, UnitPerMille, UnitPerTenThousand, UnitBasisPoint, UnitBasisPoints}
+)
+
+// JoinUnit returns a func like Join, that formats the value in the given unit, e.g. 0.125 is formatted as 12.5% for
+// UnitPercent, the output can be parsed by a Parser with the unit in Units.
+//
+// NOTE: to round in the unit, e.g. to 1 decimal place of a percentage, use FormatUnit, or ApplyMode with n adjusted
+// by the Exponent of the unit.
+func JoinUnit(unit Unit) func(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (string, bool) {
+	return func(signbit bool, integer []rune, fractional []rune, exponential int, ok bool) (string, bool) {
+		s, ok := Join(signbit, integer, fractional, exponential-unit.Exponent, ok)
+		if !ok {
+			return "", false
+		}
+		return s + unit.Suffix, true
+	}
+}
+
+// FormatUnit is like DecimalMode, but formats the value in the given unit, rounded to n decimal places of the unit,
+// e.g. FormatUnit(0.12345, UnitPercent, 1, RoundHalfEven) is "12.3%", where the infinities and NaN are formatted
+// like String, with the suffix appended.
+func FormatUnit(v interface{}, unit Unit, n int, mode RoundingMode) (string, bool) {
+	if x, ok := nonFinite(v); ok {
+		return x.FormatUnit(unit, n, mode)
+	}
+	return JoinUnit(unit)(ApplyMode(Runes(Parse(v)))(n-unit.Exponent, mode))
+}
+
+// FormatUnit formats the number in the given unit, rounded to n decimal places of the unit, see the FormatUnit
+// function, or false if the mode is not valid, note that the special values are formatted like String, with the
+// suffix appended.
+func (x Number) FormatUnit(unit Unit, n int, mode RoundingMode) (string, bool) {
+	if s, ok := x.specialString(); ok {
+		if !mode.valid() {
+			return "", false
+		}
+		return s + unit.Suffix, true
+	}
+	return JoinUnit(unit)(ApplyMode(x.Runes())(n-unit.Exponent, mode))
+}
+
+// unit strips the longest matching suffix in Parser.Units from the text, returning the exponent of the unit, or 0.
+func (sc *scanner) unit() int {
+	var match *Unit
+	for i := range sc.parser.Units {
+		u := &sc.parser.Units[i]
+		if l := len(u.Suffix); l != 0 && l < len(sc.text) && strings.EqualFold(sc.text[len(sc.text)-l:], u.Suffix) &&
+			(match == nil || l > len(match.Suffix)) {
+			match = u
+		}
+	}
+	if match == nil {
+		return 0
+	}
+	end := len(sc.text) - len(match.Suffix)
+	sc.text, sc.offsets = sc.text[:end], sc.offsets[:end+1]
+	return match.Exponent
+}
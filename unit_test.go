@@ -0,0 +1,143 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func ExampleUnit() {
+	p := Parser{Units: PercentUnits}
+	for _, s := range []string{"12.5%", "3‰", "25bp", "25 BPS", "-1.5‱", "0.5"} {
+		fmt.Println(p.ParseString(s))
+	}
+
+	// Output:
+	// false 12 5 -2 <nil>
+	// false 3  -3 <nil>
+	// false 25  -4 <nil>
+	// false 25  -4 <nil>
+	// true 1 5 -4 <nil>
+	// false  5 0 <nil>
+}
+
+func ExampleFormatUnit() {
+	fmt.Println(FormatUnit(0.12345, UnitPercent, 1, RoundHalfEven))
+	fmt.Println(FormatUnit("0.0025", UnitBasisPoint, 0, RoundHalfEven))
+	fmt.Println(FormatUnit("-0.0031", UnitPerMille, 0, RoundHalfEven))
+	fmt.Println(JoinUnit(UnitPercent)(Runes(Parse(1))))
+
+	// Output:
+	// 12.3% true
+	// 25bp true
+	// -3‰ true
+	// 100% true
+}
+
+func TestUnit_parse(t *testing.T) {
+	p := Parser{Units: PercentUnits}
+	for _, tc := range []struct {
+		Input string
+		Value string
+		Err   string
+	}{
+		{"100%", "1", ""},
+		{"1e2%", "1", ""},
+		{" 50 % ", "0.5", ""},
+		{"1bP", "0.0001", ""},
+		{"0%", "0", ""},
+		{"%", "", `round: parsing "%": invalid syntax at offset 0`},
+		{"1%%", "", `round: parsing "1%%": invalid syntax at offset 1`},
+		{"1.5x%", "", `round: parsing "1.5x%": invalid syntax at offset 3`},
+		{"1e%", "", `round: parsing "1e%": invalid syntax at offset 2`},
+		{"1e" + strconv.Itoa(math.MinInt) + "%", "", `round: parsing "1e` + strconv.Itoa(math.MinInt) + `%": exponent out of range at offset ` + strconv.Itoa(len(strconv.Itoa(math.MinInt))+2)},
+		{"b", "", `round: parsing "b": invalid syntax at offset 0`},
+	} {
+		x, err := p.ParseNumber(tc.Input)
+		if err != nil {
+			if err.Error() != tc.Err {
+				t.Errorf("%q: unexpected error: %v", tc.Input, err)
+			}
+			continue
+		}
+		if tc.Err != "" || x.Normalize().String() != tc.Value {
+			t.Errorf("%q: unexpected result: %s", tc.Input, x)
+		}
+	}
+
+	// units are only parsed if configured
+	if _, err := ParseNumber("1%"); !errors.Is(err, ErrSyntax) {
+		t.Error(err)
+	}
+
+	// the longest suffix wins, and custom units are supported
+	p = Parser{Units: []Unit{{Suffix: "k", Exponent: 3}, {Suffix: "ppm", Exponent: -6}, {Suffix: "m", Exponent: -3}, {}}}
+	for _, tc := range [][2]string{{"1.5k", "1500"}, {"2ppm", "0.000002"}, {"2m", "0.002"}} {
+		if x, err := p.ParseNumber(tc[0]); err != nil || x.Normalize().String() != tc[1] {
+			t.Error(tc, x, err)
+		}
+	}
+
+	// limits apply after the unit
+	p = Parser{Units: PercentUnits, Limits: Limits{MaxExponent: 3}}
+	if _, err := p.ParseNumber("1e2%"); err != nil {
+		t.Error(err)
+	}
+	if _, err := p.ParseNumber("1e-2%"); !errors.Is(err, ErrLimit) {
+		t.Error(err)
+	}
+}
+
+func TestUnit_roundTrip(t *testing.T) {
+	p := Parser{Units: PercentUnits}
+	for _, u := range PercentUnits {
+		for _, s := range []string{"0", "1", "-0.125", "123.456e7", "1e-20"} {
+			x, _ := ParseNumber(s)
+			formatted, ok := JoinUnit(u)(x.Runes())
+			if !ok {
+				t.Fatal(u, s)
+			}
+			y, err := p.ParseNumber(formatted)
+			if err != nil || y.Cmp(x) != 0 {
+				t.Error(u, s, formatted, y, err)
+			}
+		}
+	}
+}
+
+func TestNumber_FormatUnit(t *testing.T) {
+	x, _ := ParseNumber("0.12345")
+	if s, ok := x.FormatUnit(UnitPercent, 2, RoundDown); s != "12.34%" || !ok {
+		t.Error(s, ok)
+	}
+	if s, ok := x.FormatUnit(UnitPercent, 2, RoundingMode(-1)); s != "" || ok {
+		t.Error(s, ok)
+	}
+	if s, ok := Inf(-1).FormatUnit(UnitPercent, 2, RoundDown); s != "-Inf%" || !ok {
+		t.Error(s, ok)
+	}
+	if s, ok := NaN().FormatUnit(UnitPercent, 2, RoundingMode(-1)); s != "" || ok {
+		t.Error(s, ok)
+	}
+	if s, ok := FormatUnit("invalid", UnitPercent, 2, RoundDown); s != "" || ok {
+		t.Error(s, ok)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// Decimaler may be implemented by types that want to provide their own decimal digits to String (and therefore
+// Parse, Decimal, etc), using the same format as Parse, where ok=false will result in an unparseable string.
+//
+// Number implements Decimaler.
+type Decimaler interface {
+	Parts() (signbit bool, integer string, fractional string, exponential int, ok bool)
+}
+
+// formatValue implements String, StringShortest, and StringExact, which differ only in how floats are formatted,
+// where bitSize is 32 for float32 (and complex64), and 64 otherwise.
+func formatValue(v interface{}, float func(f float64, bitSize int) string) string {
+	switch value := v.(type) {
+	case float32:
+		return float(float64(value), 32)
+	case float64:
+		return float(value, 64)
+	case complex64:
+		if imag(value) == 0 {
+			return float(float64(real(value)), 32)
+		}
+	case complex128:
+		if imag(value) == 0 {
+			return float(real(value), 64)
+		}
+	case json.Number:
+		return string(value)
+	case *big.Int:
+		if value != nil {
+			return value.String()
+		}
+	case *big.Float:
+		if value != nil {
+			return NewNumberBigFloat(value).String()
+		}
+	case *big.Rat:
+		if value != nil {
+			if x, ok := newNumberTerminating(value); ok {
+				return x.String()
+			}
+			num, den := ratNumbers(value)
+			x, _, _ := num.QuoSignificant(den, FormatRatDigits, RoundHalfEven)
+			return x.String()
+		}
+	case Number:
+		return value.String()
+	case *Number:
+		if value != nil {
+			return value.String()
+		}
+	case Decimaler:
+		// guard against a nil pointer, where Parts has a value receiver
+		if rv := reflect.ValueOf(value); rv.Kind() != reflect.Ptr || !rv.IsNil() {
+			s, _ := Join(Runes(value.Parts()))
+			return s
+		}
+	}
+
+	// handle named types, and pointers, via reflection
+	if v != nil {
+		switch rv := reflect.ValueOf(v); rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return strconv.FormatInt(rv.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return strconv.FormatUint(rv.Uint(), 10)
+		case reflect.Float32:
+			return float(rv.Float(), 32)
+		case reflect.Float64:
+			return float(rv.Float(), 64)
+		case reflect.String:
+			return rv.String()
+		case reflect.Ptr:
+			if !rv.IsNil() {
+				return formatValue(rv.Elem().Interface(), float)
+			}
+		}
+	}
+
+	return fmt.Sprint(v)
+}
+
+// formatFloat is the float formatter for String, using FormatFloat32 or FormatFloat64.
+func formatFloat(f float64, bitSize int) string {
+	if bitSize == 32 {
+		return fmt.Sprintf(FormatFloat32, float32(f))
+	}
+	return fmt.Sprintf(FormatFloat64, f)
+}
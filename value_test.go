@@ -0,0 +1,191 @@
+/*
+   Copyright 2018 Joseph Cumines
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+ */
+
+package round
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+type mockDecimaler struct {
+	Cents int64
+}
+
+func (m mockDecimaler) Parts() (signbit bool, integer string, fractional string, exponential int, ok bool) {
+	signbit, integer, fractional, exponential, ok = Parse(m.Cents)
+	return signbit, integer, fractional, exponential - 2, ok
+}
+
+func ExampleDecimaler() {
+	fmt.Println(Decimal(mockDecimaler{Cents: -12345}, 1))
+
+	// Output:
+	// -123.5 true
+}
+
+func ExampleString_types() {
+	f := 1.5
+	fmt.Println(String(int8(-128)))
+	fmt.Println(String(uint64(math.MaxUint64)))
+	fmt.Println(String(&f))
+	fmt.Println(String(complex(2.5, 0)))
+	fmt.Println(String(json.Number("1.5e3")))
+	fmt.Println(String(new(big.Int).Lsh(big.NewInt(1), 70)))
+	fmt.Println(String(big.NewFloat(0.1)))
+	fmt.Println(String(big.NewRat(-7, 40)))
+	fmt.Println(String(big.NewRat(1, 3)))
+	fmt.Println(String(time.Millisecond))
+
+	// Output:
+	// -128
+	// 18446744073709551615
+	// 1.5
+	// 2.5
+	// 1.5e3
+	// 1180591620717411303424
+	// 0.1000000000000000055511151231257827021181583404541015625
+	// -0.175
+	// 0.3333333333333333333333333333333333
+	// 1000000
+}
+
+type (
+	namedFloat32 float32
+	namedFloat64 float64
+	namedString  string
+)
+
+func TestString_types(t *testing.T) {
+	var (
+		i     = 42
+		pi    = &i
+		ppi   = &pi
+		nilPi *int
+		x, _  = ParseNumber("-1.25e-3")
+		nilN  *Number
+		nilD  *mockDecimaler
+	)
+	for _, tc := range []struct {
+		Value    interface{}
+		String   string
+		Shortest string
+	}{
+		{nil, "<nil>", "<nil>"},
+		{int(-1), "-1", "-1"},
+		{int16(math.MinInt16), "-32768", "-32768"},
+		{int32(math.MaxInt32), "2147483647", "2147483647"},
+		{int64(math.MinInt64), "-9223372036854775808", "-9223372036854775808"},
+		{uint(7), "7", "7"},
+		{uint8(255), "255", "255"},
+		{uint16(65535), "65535", "65535"},
+		{uint32(math.MaxUint32), "4294967295", "4294967295"},
+		{uintptr(9), "9", "9"},
+		{float32(0.1), "0.100000001", "0.1"},
+		{namedFloat32(0.1), "0.100000001", "0.1"},
+		{namedFloat64(0.1), "0.10000000000000001", "0.1"},
+		{complex64(complex(0.1, 0)), "0.100000001", "0.1"},
+		{complex(0.1, 0), "0.10000000000000001", "0.1"},
+		{complex(0.1, 1), "(0.1+1i)", "(0.1+1i)"},
+		{namedString("12.5"), "12.5", "12.5"},
+		{json.Number("-0.5"), "-0.5", "-0.5"},
+		{(*big.Int)(nil), "<nil>", "<nil>"},
+		{(*big.Float)(nil), "<nil>", "<nil>"},
+		{(*big.Rat)(nil), "<nil>", "<nil>"},
+		{big.NewInt(-5), "-5", "-5"},
+		{new(big.Float).SetInf(true), "-Inf", "-Inf"},
+		{big.NewRat(3, 1), "3", "3"},
+		{big.NewRat(1, 1024), "0.0009765625", "0.0009765625"},
+		{big.NewRat(1, 12), "0.08333333333333333333333333333333333", "0.08333333333333333333333333333333333"},
+		{big.NewRat(-2, 3), "-0.6666666666666666666666666666666667", "-0.6666666666666666666666666666666667"},
+		{big.NewRat(1e12, 7), "142857142857.1428571428571428571429", "142857142857.1428571428571428571429"},
+		{x, "-0.00125", "-0.00125"},
+		{&x, "-0.00125", "-0.00125"},
+		{Inf(1), "+Inf", "+Inf"},
+		{nilN, "<nil>", "<nil>"},
+		{mockDecimaler{Cents: 5}, "0.05", "0.05"},
+		{&mockDecimaler{Cents: 5}, "0.05", "0.05"},
+		{nilD, "<nil>", "<nil>"},
+		{pi, "42", "42"},
+		{ppi, "42", "42"},
+		{nilPi, "<nil>", "<nil>"},
+		{struct{}{}, "{}", "{}"},
+	} {
+		if s := String(tc.Value); s != tc.String {
+			t.Errorf("String(%#v) = %q", tc.Value, s)
+		}
+		if s := StringShortest(tc.Value); s != tc.Shortest {
+			t.Errorf("StringShortest(%#v) = %q", tc.Value, s)
+		}
+	}
+	f := 0.1
+	if s := StringExact(&f); s != StringExact(f) {
+		t.Error(s)
+	}
+	if s := StringExact(complex(0.5, 0)); s != "0.5" {
+		t.Error(s)
+	}
+}
+
+func TestDecimal_bigRat(t *testing.T) {
+	for _, tc := range []struct {
+		Value  *big.Rat
+		N      int
+		Output string
+	}{
+		{big.NewRat(1, 3), 2, "0.33"},
+		{big.NewRat(2, 3), 2, "0.67"},
+		{big.NewRat(-1, 3), 0, "0"},
+		{big.NewRat(-5, 3), 1, "-1.7"},
+		{big.NewRat(22, 7), 5, "3.14286"},
+		{big.NewRat(1, 8), 2, "0.13"},
+	} {
+		if s, ok := Decimal(tc.Value, tc.N); !ok || s != tc.Output {
+			t.Error(tc.Value, tc.N, s, ok)
+		}
+	}
+	if _, _, _, _, ok := Parse(big.NewRat(1, 3)); !ok {
+		t.Error(ok)
+	}
+}
+
+func TestString_bigRatTerminating(t *testing.T) {
+	for num := int64(-50); num <= 50; num++ {
+		for den := int64(1); den <= 200; den++ {
+			r := big.NewRat(num, den)
+			x, ok := newNumberTerminating(r)
+			d := new(big.Int).Set(r.Denom())
+			for _, p := range []int64{2, 5} {
+				for new(big.Int).Mod(d, big.NewInt(p)).Sign() == 0 {
+					d.Quo(d, big.NewInt(p))
+				}
+			}
+			if ok != (d.Cmp(big.NewInt(1)) == 0) {
+				t.Fatal(r, ok)
+			}
+			if !ok {
+				continue
+			}
+			if v, _ := x.BigRat(); v.Cmp(r) != 0 {
+				t.Fatal(r, x)
+			}
+		}
+	}
+}